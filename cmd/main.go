@@ -1,50 +1,213 @@
 package main
 
 import (
+	"avito2/internal/config"
 	"avito2/internal/db"
+	"avito2/internal/grpc_server"
 	"avito2/internal/handler_manager"
+	"avito2/internal/logger"
 	"avito2/internal/middleware"
+	"avito2/internal/pb/pvz_v1"
 	"avito2/internal/repository"
 	"avito2/internal/service"
 	"avito2/internal/utils"
 	"context"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	httpPort := os.Getenv("SERVER_PORT")
+	slog.SetDefault(logger.New(os.Stdout))
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(os.Args[2:])
+	} else {
+		err = run(os.Args[1:])
+	}
+	if err != nil {
+		slog.Error("service stopped with error", "err", err)
+		os.Exit(1)
+	}
+}
 
-	database, err := db.NewDb(ctx)
+func run(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	autoMigrate := flags.Bool("auto-migrate", false, "apply pending migrations before start (same as DATABASE_AUTO_MIGRATE=true)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
 	if err != nil {
-		log.Fatal(err)
-		return
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *autoMigrate || cfg.Database.AutoMigrate {
+		if err := migrateUp(ctx, cfg.Database); err != nil {
+			return err
+		}
 	}
-	defer database.GetPool(ctx).Close()
+
+	database, err := db.NewDb(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() {
+		database.GetPool(ctx).Close()
+		slog.Info("database pool closed")
+	}()
 
 	repo := repository.NewRepository(database)
 	svc := service.NewService(repo)
-	jwtGen := &utils.JWTGen{}
-	hm := handler_manager.NewHandlerManager(svc, jwtGen)
+	userRepo := repository.NewUserRepository(database)
+	userSvc := service.NewUserService(userRepo, cfg.Auth.RefreshTokenTTL)
+	keys, err := loadKeySet(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to load jwt keys: %w", err)
+	}
+	jwtGen := utils.NewJWTGen(keys, cfg.Auth.TokenTTL)
+	hm := handler_manager.NewHandlerManager(svc, userSvc, jwtGen)
+
+	auth := middleware.NewAuthMiddleware(keys, userSvc)
 
 	r := mux.NewRouter()
-	r.Handle("/pvz", middleware.AuthMiddleware(http.HandlerFunc(hm.Pvz)))
-	r.Handle("/pvz/{pvzId}/close_last_reception", middleware.AuthMiddleware(http.HandlerFunc(hm.CloseLastReception)))
-	r.Handle("/pvz/{pvzId}/delete_last_product", middleware.AuthMiddleware(http.HandlerFunc(hm.DeleteLastProduct)))
-	r.Handle("/receptions", middleware.AuthMiddleware(http.HandlerFunc(hm.CreateReception)))
-	r.Handle("/products", middleware.AuthMiddleware(http.HandlerFunc(hm.AddProduct)))
-	r.HandleFunc("/dummyLogin", hm.DummyLogin)
+	r.Use(middleware.RequestIdMiddleware)
+	r.Use(middleware.MetricsMiddleware)
+	r.Handle("/pvz", auth.Handle(http.HandlerFunc(hm.Pvz)))
+	r.Handle("/pvz/nearest", auth.Handle(http.HandlerFunc(hm.NearestPvz)))
+	r.Handle("/pvz/{pvzId}", auth.Handle(http.HandlerFunc(hm.PvzById)))
+	r.Handle("/pvz/{pvzId}/close_last_reception", auth.Handle(http.HandlerFunc(hm.CloseLastReception)))
+	r.Handle("/pvz/{pvzId}/delete_last_product", auth.Handle(http.HandlerFunc(hm.DeleteLastProduct)))
+	r.Handle("/receptions", auth.Handle(http.HandlerFunc(hm.CreateReception)))
+	r.Handle("/receptions/{receptionId}/cancel", auth.Handle(http.HandlerFunc(hm.CancelReception)))
+	r.Handle("/receptions/{receptionId}/reopen", auth.Handle(http.HandlerFunc(hm.ReopenReception)))
+	r.Handle("/receptions/{receptionId}/products/{productId}", auth.Handle(http.HandlerFunc(hm.DeleteProduct)))
+	r.Handle("/products", auth.Handle(http.HandlerFunc(hm.AddProduct)))
+	r.Handle("/products/batch", auth.Handle(http.HandlerFunc(hm.AddProductsBatch)))
+	r.Handle("/products/lookup", auth.Handle(http.HandlerFunc(hm.ProductLookup)))
+	r.Handle("/catalog", auth.Handle(http.HandlerFunc(hm.CreateCatalogItem)))
+	r.Handle("/catalog/{code}", auth.Handle(http.HandlerFunc(hm.GetCatalogItem)))
+	r.Handle("/product_types", auth.Handle(http.HandlerFunc(hm.ProductTypes)))
+	r.Handle("/product_types/{name}", auth.Handle(http.HandlerFunc(hm.ProductType)))
+	r.Handle("/cities", auth.Handle(http.HandlerFunc(hm.Cities)))
+	r.Handle("/cities/{name}", auth.Handle(http.HandlerFunc(hm.City)))
+	r.HandleFunc("/.well-known/jwks.json", hm.JWKS)
+	r.HandleFunc("/register", hm.Register)
+	r.HandleFunc("/login", hm.Login)
+	r.HandleFunc("/auth/refresh", hm.Refresh)
+	r.Handle("/auth/logout", auth.Handle(http.HandlerFunc(hm.Logout)))
+	if cfg.Auth.DummyLoginEnabled {
+		r.HandleFunc("/dummyLogin", hm.DummyLogin)
+	}
 
-	log.Println("http serer start listening on port:", httpPort)
-	err = http.ListenAndServe(":"+httpPort, r)
+	httpServer := newHttpServer(":"+cfg.HTTP.Port, r, cfg.HTTP)
 
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+	metricsServer := newHttpServer(":"+cfg.Metrics.Port, metricsMux, cfg.HTTP)
+
+	lis, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("failed to listen grpc port: %w", err)
+	}
+	grpcServer := grpc.NewServer()
+	pvz_v1.RegisterPVZServiceServer(grpcServer, grpc_server.NewPvzServer(svc))
+
+	go userSvc.RunTokenCleanup(ctx, service.TokenCleanupInterval)
+
+	errCh := make(chan error, 3)
+	go func() {
+		slog.Info("grpc server start listening", "port", cfg.GRPC.Port)
+		if err := grpcServer.Serve(lis); err != nil {
+			errCh <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+	go func() {
+		slog.Info("metrics server start listening", "port", cfg.Metrics.Port)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("metrics server: %w", err)
+		}
+	}()
+	go func() {
+		slog.Info("http server start listening", "port", cfg.HTTP.Port)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining in-flight requests", "timeout", cfg.HTTP.ShutdownTimeout.String())
+	case runErr = <-errCh:
+		slog.Error("server failed, shutting down", "err", runErr)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to gracefully shutdown http server", "err", err)
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to gracefully shutdown metrics server", "err", err)
+	}
+	stopGrpcServer(shutdownCtx, grpcServer)
+	slog.Info("servers stopped")
+
+	return runErr
+}
+
+// loadKeySet reads the signing keys from the configured directory. Without one
+// (config validation allows that only with JWT_ALLOW_EPHEMERAL_KEY) the service
+// signs with a throwaway key, which only suits local development.
+func loadKeySet(cfg config.AuthConfig) (*utils.KeySet, error) {
+	if cfg.KeysDir == "" {
+		slog.Warn("JWT_KEYS_DIR is not set, signing tokens with an ephemeral key")
+		return utils.NewEphemeralKeySet()
+	}
+	return utils.LoadKeySet(cfg.KeysDir, cfg.SigningKeyId)
+}
+
+func newHttpServer(addr string, handler http.Handler, cfg config.HTTPConfig) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// stopGrpcServer waits for in-flight RPCs to finish and forcibly closes the
+// remaining connections once ctx expires.
+func stopGrpcServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Error("grpc server did not stop in time, closing connections")
+		server.Stop()
 	}
 }
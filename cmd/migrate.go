@@ -0,0 +1,94 @@
+package main
+
+import (
+	"avito2/internal/config"
+	"avito2/internal/db"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// runMigrate handles "migrate up|down|status" with the embedded migrations.
+func runMigrate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s migrate up|down|status", os.Args[0])
+	}
+
+	cfg, err := config.LoadDatabase(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch args[0] {
+	case "up":
+		return migrateUp(ctx, *cfg)
+	case "down":
+		return migrateDown(ctx, *cfg)
+	case "status":
+		return migrateStatus(ctx, *cfg)
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", args[0])
+	}
+}
+
+func migrateUp(ctx context.Context, cfg config.DatabaseConfig) error {
+	migrator, err := db.NewMigrator(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create migrator: %w", err)
+	}
+	defer migrator.Close()
+
+	results, err := migrator.Up(ctx)
+	for _, res := range results {
+		slog.Info("migration applied", "migration", res.Source.Path, "duration", res.Duration.String())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	if len(results) == 0 {
+		slog.Info("no pending migrations")
+	}
+	return nil
+}
+
+func migrateDown(ctx context.Context, cfg config.DatabaseConfig) error {
+	migrator, err := db.NewMigrator(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create migrator: %w", err)
+	}
+	defer migrator.Close()
+
+	res, err := migrator.Down(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration: %w", err)
+	}
+	slog.Info("migration rolled back", "migration", res.Source.Path, "duration", res.Duration.String())
+	return nil
+}
+
+func migrateStatus(ctx context.Context, cfg config.DatabaseConfig) error {
+	migrator, err := db.NewMigrator(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create migrator: %w", err)
+	}
+	defer migrator.Close()
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+	for _, status := range statuses {
+		attrs := []any{"migration", status.Source.Path, "state", string(status.State)}
+		if !status.AppliedAt.IsZero() {
+			attrs = append(attrs, "applied_at", status.AppliedAt)
+		}
+		slog.Info("migration status", attrs...)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	HTTP     HTTPConfig     `yaml:"http"`
+	GRPC     GRPCConfig     `yaml:"grpc"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+}
+
+type HTTPConfig struct {
+	Port              string        `yaml:"port"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+}
+
+type GRPCConfig struct {
+	Port string `yaml:"port"`
+}
+
+type MetricsConfig struct {
+	Port string `yaml:"port"`
+}
+
+type DatabaseConfig struct {
+	URL             string        `yaml:"url"`
+	MaxConns        int32         `yaml:"max_conns"`
+	MinConns        int32         `yaml:"min_conns"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time"`
+	AutoMigrate     bool          `yaml:"auto_migrate"`
+}
+
+type AuthConfig struct {
+	KeysDir           string        `yaml:"keys_dir"`
+	SigningKeyId      string        `yaml:"signing_key_id"`
+	AllowEphemeralKey bool          `yaml:"allow_ephemeral_key"`
+	TokenTTL          time.Duration `yaml:"token_ttl"`
+	RefreshTokenTTL   time.Duration `yaml:"refresh_token_ttl"`
+	DummyLoginEnabled bool          `yaml:"dummy_login_enabled"`
+}
+
+func defaults() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Port:              "8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   15 * time.Second,
+		},
+		GRPC: GRPCConfig{
+			Port: "3000",
+		},
+		Metrics: MetricsConfig{
+			Port: "9000",
+		},
+		Database: DatabaseConfig{
+			MaxConns:        10,
+			MinConns:        2,
+			MaxConnLifetime: time.Hour,
+			MaxConnIdleTime: 30 * time.Minute,
+		},
+		Auth: AuthConfig{
+			TokenTTL:        15 * time.Minute,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+		},
+	}
+}
+
+// Load builds the configuration from defaults, then the optional YAML file at
+// path, then environment variables, and validates the result. Environment
+// variables take precedence over the file.
+func Load(path string) (*Config, error) {
+	cfg, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// LoadDatabase builds the configuration like Load but validates only the
+// database section, so that migrations can run with just DATABASE_URL.
+func LoadDatabase(path string) (*DatabaseConfig, error) {
+	cfg, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Database.validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg.Database, nil
+}
+
+func load(path string) (*Config, error) {
+	cfg := defaults()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (c *Config) loadEnv() error {
+	var errs []error
+	envString("SERVER_PORT", &c.HTTP.Port)
+	errs = append(errs, envDuration("HTTP_READ_HEADER_TIMEOUT", &c.HTTP.ReadHeaderTimeout))
+	errs = append(errs, envDuration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout))
+	errs = append(errs, envDuration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout))
+	errs = append(errs, envDuration("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout))
+	errs = append(errs, envDuration("SHUTDOWN_TIMEOUT", &c.HTTP.ShutdownTimeout))
+	envString("GRPC_PORT", &c.GRPC.Port)
+	envString("METRICS_PORT", &c.Metrics.Port)
+	envString("DATABASE_URL", &c.Database.URL)
+	errs = append(errs, envInt32("DATABASE_MAX_CONNS", &c.Database.MaxConns))
+	errs = append(errs, envInt32("DATABASE_MIN_CONNS", &c.Database.MinConns))
+	errs = append(errs, envDuration("DATABASE_MAX_CONN_LIFETIME", &c.Database.MaxConnLifetime))
+	errs = append(errs, envDuration("DATABASE_MAX_CONN_IDLE_TIME", &c.Database.MaxConnIdleTime))
+	errs = append(errs, envBool("DATABASE_AUTO_MIGRATE", &c.Database.AutoMigrate))
+	envString("JWT_KEYS_DIR", &c.Auth.KeysDir)
+	envString("JWT_SIGNING_KEY_ID", &c.Auth.SigningKeyId)
+	errs = append(errs, envBool("JWT_ALLOW_EPHEMERAL_KEY", &c.Auth.AllowEphemeralKey))
+	errs = append(errs, envDuration("TOKEN_TTL", &c.Auth.TokenTTL))
+	errs = append(errs, envDuration("REFRESH_TOKEN_TTL", &c.Auth.RefreshTokenTTL))
+	errs = append(errs, envBool("DUMMY_LOGIN_ENABLED", &c.Auth.DummyLoginEnabled))
+	return errors.Join(errs...)
+}
+
+func (c *Config) validate() error {
+	errs := []error{c.Database.validate()}
+	if c.Auth.KeysDir == "" && !c.Auth.AllowEphemeralKey {
+		errs = append(errs, errors.New("jwt keys directory is required (JWT_KEYS_DIR); set JWT_ALLOW_EPHEMERAL_KEY=true to sign with a throwaway key in local development"))
+	}
+	if c.Auth.SigningKeyId != "" && c.Auth.KeysDir == "" {
+		errs = append(errs, errors.New("signing key id requires a keys directory (JWT_KEYS_DIR)"))
+	}
+	if c.HTTP.Port == "" || c.GRPC.Port == "" || c.Metrics.Port == "" {
+		errs = append(errs, errors.New("http, grpc and metrics ports must not be empty"))
+	}
+	if c.Auth.TokenTTL <= 0 || c.Auth.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("token and refresh token ttl must be positive"))
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown timeout must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *DatabaseConfig) validate() error {
+	var errs []error
+	if c.URL == "" {
+		errs = append(errs, errors.New("database url is required (DATABASE_URL)"))
+	}
+	if c.MaxConns < 1 || c.MinConns < 0 || c.MinConns > c.MaxConns {
+		errs = append(errs, errors.New("database pool sizes must satisfy 0 <= min_conns <= max_conns and max_conns >= 1"))
+	}
+	return errors.Join(errs...)
+}
+
+func envString(name string, dst *string) {
+	if v, ok := os.LookupEnv(name); ok {
+		*dst = v
+	}
+}
+
+func envDuration(name string, dst *time.Duration) error {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %w", name, err)
+	}
+	*dst = d
+	return nil
+}
+
+func envInt32(name string, dst *int32) error {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	i, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %w", name, err)
+	}
+	*dst = int32(i)
+	return nil
+}
+
+func envBool(name string, dst *bool) error {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %w", name, err)
+	}
+	*dst = b
+	return nil
+}
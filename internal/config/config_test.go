@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Load(t *testing.T) {
+	t.Run("defaults with required env", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("JWT_KEYS_DIR", "/etc/pvz/keys")
+
+		cfg, err := Load("")
+
+		require.NoError(t, err)
+		require.Equal(t, "8080", cfg.HTTP.Port)
+		require.Equal(t, "3000", cfg.GRPC.Port)
+		require.Equal(t, "9000", cfg.Metrics.Port)
+		require.Equal(t, 15*time.Minute, cfg.Auth.TokenTTL)
+		require.Equal(t, 30*24*time.Hour, cfg.Auth.RefreshTokenTTL)
+		require.Equal(t, int32(10), cfg.Database.MaxConns)
+		require.False(t, cfg.Auth.DummyLoginEnabled)
+		require.False(t, cfg.Auth.AllowEphemeralKey)
+	})
+	t.Run("missing keys dir", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("JWT_KEYS_DIR", "")
+
+		_, err := Load("")
+
+		require.ErrorContains(t, err, "JWT_KEYS_DIR")
+	})
+	t.Run("ephemeral key allowed", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("JWT_KEYS_DIR", "")
+		t.Setenv("JWT_ALLOW_EPHEMERAL_KEY", "true")
+
+		cfg, err := Load("")
+
+		require.NoError(t, err)
+		require.True(t, cfg.Auth.AllowEphemeralKey)
+	})
+	t.Run("missing database url", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "")
+
+		_, err := Load("")
+
+		require.ErrorContains(t, err, "DATABASE_URL")
+	})
+	t.Run("signing key id without keys dir", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("JWT_KEYS_DIR", "")
+		t.Setenv("JWT_SIGNING_KEY_ID", "2025-05")
+
+		_, err := Load("")
+
+		require.ErrorContains(t, err, "JWT_KEYS_DIR")
+	})
+	t.Run("file overridden by env", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		err := os.WriteFile(path, []byte(`
+http:
+  port: "8081"
+  shutdown_timeout: 5s
+database:
+  url: postgres://file/db
+  max_conns: 20
+auth:
+  keys_dir: /file/keys
+  token_ttl: 1h
+`), 0o600)
+		require.NoError(t, err)
+		t.Setenv("DATABASE_MAX_CONNS", "30")
+		t.Setenv("JWT_KEYS_DIR", "/env/keys")
+
+		cfg, err := Load(path)
+
+		require.NoError(t, err)
+		require.Equal(t, "8081", cfg.HTTP.Port)
+		require.Equal(t, 5*time.Second, cfg.HTTP.ShutdownTimeout)
+		require.Equal(t, "postgres://file/db", cfg.Database.URL)
+		require.Equal(t, int32(30), cfg.Database.MaxConns)
+		require.Equal(t, "/env/keys", cfg.Auth.KeysDir)
+		require.Equal(t, time.Hour, cfg.Auth.TokenTTL)
+	})
+	t.Run("invalid env value", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+
+		_, err := Load("")
+
+		require.ErrorContains(t, err, "SHUTDOWN_TIMEOUT")
+	})
+	t.Run("invalid pool sizes", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("DATABASE_MIN_CONNS", "5")
+		t.Setenv("DATABASE_MAX_CONNS", "2")
+
+		_, err := Load("")
+
+		require.Error(t, err)
+	})
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		require.Error(t, err)
+	})
+}
+
+func Test_LoadDatabase(t *testing.T) {
+	t.Run("only database url", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://localhost/db")
+		t.Setenv("JWT_KEYS_DIR", "")
+
+		cfg, err := LoadDatabase("")
+
+		require.NoError(t, err)
+		require.Equal(t, "postgres://localhost/db", cfg.URL)
+		require.Equal(t, int32(10), cfg.MaxConns)
+	})
+	t.Run("missing database url", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "")
+
+		_, err := LoadDatabase("")
+
+		require.ErrorContains(t, err, "DATABASE_URL")
+	})
+}
@@ -1,14 +1,23 @@
 package db
 
 import (
+	"avito2/internal/config"
 	"context"
-	"os"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
-func NewDb(ctx context.Context) (*Database, error) {
-	pool, err := pgxpool.Connect(ctx, os.Getenv("DATABASE_URL"))
+func NewDb(ctx context.Context, cfg config.DatabaseConfig) (*Database, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	poolCfg.MaxConns = cfg.MaxConns
+	poolCfg.MinConns = cfg.MinConns
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+
+	pool, err := pgxpool.ConnectConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,7 @@ type DBops interface {
 	BeginTx(ctx context.Context, options *pgx.TxOptions) (pgx.Tx, error)
 	Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error)
 	ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row
+	ExecQuery(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error)
 }
 
 func newDatabase(cluster *pgxpool.Pool) *Database {
@@ -38,3 +39,7 @@ func (db Database) Exec(ctx context.Context, query string, args ...interface{})
 func (db Database) ExecQueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
 	return db.cluster.QueryRow(ctx, query, args...)
 }
+
+func (db Database) ExecQuery(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	return db.cluster.Query(ctx, query, args...)
+}
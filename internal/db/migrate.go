@@ -0,0 +1,81 @@
+package db
+
+import (
+	"avito2/internal/config"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migrator applies the migrations embedded into the binary. A postgres session
+// lock is held while migrating, so several instances started at once apply
+// every migration only one time.
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// NewMigrator opens a separate database/sql connection to cfg.URL, which is
+// closed by Migrator.Close.
+func NewMigrator(cfg config.DatabaseConfig) (*Migrator, error) {
+	connConfig, err := pgx.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	fsys, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
+	provider, err := goose.NewProvider(goose.DialectPostgres, sqlDB, fsys, goose.WithSessionLocker(locker))
+	if err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+
+	return &Migrator{provider: provider}, nil
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+// UpByOne applies the next pending migration. It returns goose.ErrNoNextVersion
+// when there is nothing to apply.
+func (m *Migrator) UpByOne(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.UpByOne(ctx)
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+// Reset rolls back all applied migrations.
+func (m *Migrator) Reset(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.DownTo(ctx, 0)
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+func (m *Migrator) Close() error {
+	return m.provider.Close()
+}
@@ -27,8 +27,8 @@ CREATE INDEX idx_receptions_date_time ON receptions(date_time);
 
 -- +goose Down
 -- +goose StatementBegin
-DROP EXTENSION IF EXISTS "uuid-ossp";
-DROP TABLE pvz;
-DROP TABLE receptions;
 DROP TABLE products;
+DROP TABLE receptions;
+DROP TABLE pvz;
+DROP EXTENSION IF EXISTS "uuid-ossp";
 -- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users(
+    id uuid primary key default uuid_generate_v4(),
+    email varchar(256) not null unique,
+    password_hash varchar(256) not null,
+    role varchar(256) not null,
+    created_at timestamp not null
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE users;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_pvz_registration_date_id ON pvz(registration_date, id);
+CREATE INDEX idx_receptions_pvz_id_date_time ON receptions(pvz_id, date_time);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_receptions_pvz_id_date_time;
+DROP INDEX idx_pvz_registration_date_id;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- close duplicates left over from before the invariant was enforced by the database,
+-- keeping the most recent in-progress reception of every pvz open
+UPDATE receptions r SET status = 'close'
+WHERE r.status = 'in_progress'
+    AND EXISTS (
+        SELECT 1 FROM receptions newer
+        WHERE newer.pvz_id = r.pvz_id
+            AND newer.status = 'in_progress'
+            AND (newer.date_time, newer.id) > (r.date_time, r.id)
+    );
+CREATE UNIQUE INDEX uq_receptions_pvz_id_in_progress ON receptions(pvz_id) WHERE status = 'in_progress';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX uq_receptions_pvz_id_in_progress;
+-- +goose StatementEnd
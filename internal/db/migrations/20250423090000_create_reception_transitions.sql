@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reception_transitions(
+    id uuid primary key default uuid_generate_v4(),
+    reception_id uuid not null,
+    from_status varchar(256),
+    to_status varchar(256) not null,
+    actor_id varchar(256) not null,
+    actor_role varchar(256) not null,
+    created_at timestamp not null,
+    foreign key (reception_id) references receptions(id)
+);
+CREATE INDEX idx_reception_transitions_reception_id_created_at ON reception_transitions(reception_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reception_transitions;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE receptions ADD COLUMN closed_at timestamp, ADD COLUMN closed_by varchar(256);
+-- receptions closed before the columns existed get the time and actor of their last recorded close,
+-- those closed before transitions were recorded keep an unknown close time
+UPDATE receptions r SET closed_at = t.created_at, closed_by = t.actor_id
+FROM (
+    SELECT DISTINCT ON (reception_id) reception_id, created_at, actor_id
+    FROM reception_transitions
+    WHERE to_status = 'close'
+    ORDER BY reception_id, created_at DESC
+) t
+WHERE r.id = t.reception_id AND r.status = 'close';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE receptions DROP COLUMN closed_by, DROP COLUMN closed_at;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE product_deletions(
+    id uuid primary key default uuid_generate_v4(),
+    product_id uuid not null,
+    product_type varchar(256) not null,
+    product_date_time timestamp not null,
+    reception_id uuid not null,
+    actor_id varchar(256) not null,
+    actor_role varchar(256) not null,
+    deleted_at timestamp not null,
+    foreign key (reception_id) references receptions(id)
+);
+CREATE INDEX idx_product_deletions_reception_id_deleted_at ON product_deletions(reception_id, deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE product_deletions;
+-- +goose StatementEnd
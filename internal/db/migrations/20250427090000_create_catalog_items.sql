@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE catalog_items(
+    barcode varchar(64) primary key,
+    sku varchar(64) not null unique,
+    name varchar(256) not null,
+    type varchar(256) not null,
+    weight_grams integer not null check (weight_grams > 0),
+    length_mm integer not null check (length_mm > 0),
+    width_mm integer not null check (width_mm > 0),
+    height_mm integer not null check (height_mm > 0),
+    created_at timestamp not null
+);
+CREATE INDEX idx_products_barcode ON products(barcode) WHERE barcode IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_products_barcode;
+DROP TABLE catalog_items;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE product_types(
+    name varchar(256) primary key,
+    created_at timestamp not null
+);
+INSERT INTO product_types (name, created_at) VALUES
+    ('электроника', now()),
+    ('одежда', now()),
+    ('обувь', now());
+-- keeps any type already stored so that the foreign keys below can be added
+INSERT INTO product_types (name, created_at)
+SELECT type, now() FROM products UNION SELECT type, now() FROM catalog_items
+ON CONFLICT (name) DO NOTHING;
+ALTER TABLE products ADD CONSTRAINT fk_products_type
+    FOREIGN KEY (type) REFERENCES product_types(name) ON UPDATE CASCADE;
+ALTER TABLE catalog_items ADD CONSTRAINT fk_catalog_items_type
+    FOREIGN KEY (type) REFERENCES product_types(name) ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE catalog_items DROP CONSTRAINT fk_catalog_items_type;
+ALTER TABLE products DROP CONSTRAINT fk_products_type;
+DROP TABLE product_types;
+-- +goose StatementEnd
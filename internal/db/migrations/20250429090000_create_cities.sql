@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cities(
+    name varchar(256) primary key,
+    region varchar(256) not null,
+    timezone varchar(64) not null,
+    active boolean not null default true,
+    created_at timestamp not null
+);
+INSERT INTO cities (name, region, timezone, created_at) VALUES
+    ('Москва', 'Москва', 'Europe/Moscow', now()),
+    ('Казань', 'Республика Татарстан', 'Europe/Moscow', now()),
+    ('Санкт-Петербург', 'Санкт-Петербург', 'Europe/Moscow', now());
+ALTER TABLE pvz ADD CONSTRAINT fk_pvz_city
+    FOREIGN KEY (city) REFERENCES cities(name) ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pvz DROP CONSTRAINT fk_pvz_city;
+DROP TABLE cities;
+-- +goose StatementEnd
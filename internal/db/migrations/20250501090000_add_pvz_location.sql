@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pvz
+    ADD COLUMN address varchar(512) not null default '',
+    ADD COLUMN latitude double precision,
+    ADD COLUMN longitude double precision,
+    ADD COLUMN working_hours varchar(16) not null default '',
+    ADD CONSTRAINT chk_pvz_coordinates CHECK (
+        (latitude IS NULL) = (longitude IS NULL)
+        AND latitude BETWEEN -90 AND 90
+        AND longitude BETWEEN -180 AND 180
+    );
+-- the nearest pvz lookup narrows the search to a bounding box first
+CREATE INDEX idx_pvz_coordinates ON pvz(latitude, longitude) WHERE latitude IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_pvz_coordinates;
+ALTER TABLE pvz
+    DROP CONSTRAINT chk_pvz_coordinates,
+    DROP COLUMN working_hours,
+    DROP COLUMN longitude,
+    DROP COLUMN latitude,
+    DROP COLUMN address;
+-- +goose StatementEnd
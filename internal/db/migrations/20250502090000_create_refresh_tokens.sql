@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE refresh_tokens(
+    id uuid primary key default uuid_generate_v4(),
+    token_hash varchar(64) not null unique,
+    family_id uuid not null,
+    user_id varchar(256) not null,
+    role varchar(256) not null,
+    expires_at timestamp not null,
+    created_at timestamp not null,
+    revoked_at timestamp
+);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+-- access tokens revoked before they expire, looked up by their jti claim
+CREATE TABLE revoked_tokens(
+    jti varchar(64) primary key,
+    expires_at timestamp not null
+);
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE revoked_tokens;
+DROP TABLE refresh_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- token times are compared with the clock of the service; without a time zone
+-- they were read back shifted by the offset of the host
+ALTER TABLE refresh_tokens
+    ALTER COLUMN expires_at TYPE timestamptz,
+    ALTER COLUMN created_at TYPE timestamptz,
+    ALTER COLUMN revoked_at TYPE timestamptz;
+ALTER TABLE revoked_tokens ALTER COLUMN expires_at TYPE timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE revoked_tokens ALTER COLUMN expires_at TYPE timestamp;
+ALTER TABLE refresh_tokens
+    ALTER COLUMN revoked_at TYPE timestamp,
+    ALTER COLUMN created_at TYPE timestamp,
+    ALTER COLUMN expires_at TYPE timestamp;
+-- +goose StatementEnd
@@ -12,4 +12,36 @@ var (
 	ErrReceptionInProgressDoesNotExist  = errors.New("reception in progress does not exist")
 	ErrNoProductToDelete                = errors.New("no product to delete")
 	ErrReceptionInProgressAlreadyExists = errors.New("reception in progress already exist")
+	ErrUserAlreadyExists                = errors.New("user already exists")
+	ErrInvalidCredentials               = errors.New("invalid email or password")
+	ErrInvalidRole                      = errors.New("invalid role")
+	ErrInvalidCity                      = errors.New("invalid city")
+	ErrInvalidProductType               = errors.New("invalid product type")
+	ErrInvalidEmail                     = errors.New("invalid email")
+	ErrInvalidPassword                  = errors.New("invalid password")
+	ErrInvalidQueryParam                = errors.New("invalid query parameter")
+	ErrEmptyToken                       = errors.New("empty token")
+	ErrInvalidToken                     = errors.New("invalid or expired token")
+	ErrInvalidReceptionIdFormat         = errors.New("invalid reception id format")
+	ErrReceptionDoesNotExist            = errors.New("reception does not exist")
+	ErrInvalidReceptionTransition       = errors.New("reception status transition is not allowed")
+	ErrReceptionReopenWindowExpired     = errors.New("reception reopen window has expired")
+	ErrInvalidProductIdFormat           = errors.New("invalid product id format")
+	ErrProductDoesNotExist              = errors.New("product does not exist")
+	ErrReceptionNotInProgress           = errors.New("reception is not in progress")
+	ErrInvalidBarcode                   = errors.New("invalid barcode")
+	ErrInvalidProductBatch              = errors.New("invalid product batch")
+	ErrInvalidCatalogItem               = errors.New("invalid catalog item")
+	ErrCatalogItemDoesNotExist          = errors.New("catalog item does not exist")
+	ErrCatalogItemAlreadyExists         = errors.New("catalog item already exists")
+	ErrProductTypeDoesNotExist          = errors.New("product type does not exist")
+	ErrProductTypeAlreadyExists         = errors.New("product type already exists")
+	ErrProductTypeInUse                 = errors.New("product type is in use")
+	ErrCityDoesNotExist                 = errors.New("city does not exist")
+	ErrCityAlreadyExists                = errors.New("city already exists")
+	ErrCityInUse                        = errors.New("city is in use")
+	ErrCityInactive                     = errors.New("city is inactive")
+	ErrPvzInactive                      = errors.New("pvz is inactive")
+	ErrInvalidPvz                       = errors.New("invalid pvz")
+	ErrInvalidRefreshToken              = errors.New("invalid or expired refresh token")
 )
@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type ErrorResponse struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+type httpError struct {
+	err    error
+	code   string
+	status int
+}
+
+// httpErrors is the single place where sentinel errors are mapped to the
+// machine-readable code and HTTP status returned to clients. Codes are part of
+// the public API and must not change once released.
+var httpErrors = []httpError{
+	{ErrInvalidHtppMethod, "invalid_http_method", http.StatusMethodNotAllowed},
+	{ErrInvalidJson, "invalid_json", http.StatusBadRequest},
+	{ErrInternalServerError, "internal_error", http.StatusInternalServerError},
+	{ErrAccessDenied, "access_denied", http.StatusForbidden},
+	{ErrInvalidPvzIdFormat, "invalid_pvz_id", http.StatusBadRequest},
+	{ErrPvzDoesNotExist, "pvz_not_found", http.StatusBadRequest},
+	{ErrReceptionInProgressDoesNotExist, "reception_in_progress_not_found", http.StatusBadRequest},
+	{ErrNoProductToDelete, "no_product_to_delete", http.StatusBadRequest},
+	{ErrReceptionInProgressAlreadyExists, "reception_in_progress_already_exists", http.StatusBadRequest},
+	{ErrUserAlreadyExists, "user_already_exists", http.StatusBadRequest},
+	{ErrInvalidCredentials, "invalid_credentials", http.StatusUnauthorized},
+	{ErrInvalidRole, "invalid_role", http.StatusBadRequest},
+	{ErrInvalidCity, "invalid_city", http.StatusBadRequest},
+	{ErrInvalidProductType, "invalid_product_type", http.StatusBadRequest},
+	{ErrInvalidEmail, "invalid_email", http.StatusBadRequest},
+	{ErrInvalidPassword, "invalid_password", http.StatusBadRequest},
+	{ErrInvalidQueryParam, "invalid_query_param", http.StatusBadRequest},
+	{ErrEmptyToken, "empty_token", http.StatusUnauthorized},
+	{ErrInvalidToken, "invalid_token", http.StatusUnauthorized},
+	{ErrInvalidReceptionIdFormat, "invalid_reception_id", http.StatusBadRequest},
+	{ErrReceptionDoesNotExist, "reception_not_found", http.StatusNotFound},
+	{ErrInvalidReceptionTransition, "invalid_reception_transition", http.StatusConflict},
+	{ErrReceptionReopenWindowExpired, "reception_reopen_window_expired", http.StatusConflict},
+	{ErrInvalidProductIdFormat, "invalid_product_id", http.StatusBadRequest},
+	{ErrProductDoesNotExist, "product_not_found", http.StatusNotFound},
+	{ErrReceptionNotInProgress, "reception_not_in_progress", http.StatusConflict},
+	{ErrInvalidBarcode, "invalid_barcode", http.StatusBadRequest},
+	{ErrInvalidProductBatch, "invalid_product_batch", http.StatusBadRequest},
+	{ErrInvalidCatalogItem, "invalid_catalog_item", http.StatusBadRequest},
+	{ErrCatalogItemDoesNotExist, "catalog_item_not_found", http.StatusNotFound},
+	{ErrCatalogItemAlreadyExists, "catalog_item_already_exists", http.StatusConflict},
+	{ErrProductTypeDoesNotExist, "product_type_not_found", http.StatusNotFound},
+	{ErrProductTypeAlreadyExists, "product_type_already_exists", http.StatusConflict},
+	{ErrProductTypeInUse, "product_type_in_use", http.StatusConflict},
+	{ErrCityDoesNotExist, "city_not_found", http.StatusNotFound},
+	{ErrCityAlreadyExists, "city_already_exists", http.StatusConflict},
+	{ErrCityInUse, "city_in_use", http.StatusConflict},
+	{ErrCityInactive, "city_inactive", http.StatusConflict},
+	{ErrPvzInactive, "pvz_inactive", http.StatusConflict},
+	{ErrInvalidPvz, "invalid_pvz", http.StatusBadRequest},
+	{ErrInvalidRefreshToken, "invalid_refresh_token", http.StatusUnauthorized},
+}
+
+// DetailedError attaches client-facing details to a sentinel error.
+type DetailedError struct {
+	Err     error
+	Details map[string]any
+}
+
+func (e *DetailedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DetailedError) Unwrap() error {
+	return e.Err
+}
+
+func WithDetails(err error, details map[string]any) error {
+	return &DetailedError{Err: err, Details: details}
+}
+
+// Lookup returns the code and HTTP status for err. Errors without a mapping
+// are reported as internal errors so that their text never reaches clients.
+func Lookup(err error) (code string, status int, sentinel error) {
+	for _, e := range httpErrors {
+		if errors.Is(err, e.err) {
+			return e.code, e.status, e.err
+		}
+	}
+	return "internal_error", http.StatusInternalServerError, ErrInternalServerError
+}
+
+func WriteHttpError(w http.ResponseWriter, err error) {
+	code, status, sentinel := Lookup(err)
+	res := ErrorResponse{
+		Code:    code,
+		Message: sentinel.Error(),
+	}
+
+	var detailed *DetailedError
+	if errors.As(err, &detailed) {
+		res.Details = detailed.Details
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
+}
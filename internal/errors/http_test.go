@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WriteHttpError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mapped error", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+
+		WriteHttpError(rec, ErrPvzDoesNotExist)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var res ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "pvz_not_found", res.Code)
+		assert.Equal(t, ErrPvzDoesNotExist.Error(), res.Message)
+		assert.Nil(t, res.Details)
+	})
+
+	t.Run("wrapped error", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+
+		WriteHttpError(rec, fmt.Errorf("create reception: %w", ErrReceptionInProgressAlreadyExists))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var res ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "reception_in_progress_already_exists", res.Code)
+	})
+
+	t.Run("error with details", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+
+		WriteHttpError(rec, WithDetails(ErrInvalidQueryParam, map[string]any{"param": "page"}))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var res ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "invalid_query_param", res.Code)
+		assert.Equal(t, "page", res.Details["param"])
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+
+		WriteHttpError(rec, errors.New("connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		var res ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "internal_error", res.Code)
+		assert.Equal(t, ErrInternalServerError.Error(), res.Message)
+	})
+}
@@ -0,0 +1,81 @@
+package grpc_server
+
+import (
+	"avito2/internal/model"
+	"avito2/internal/pb/pvz_v1"
+	"avito2/internal/service"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// streamPageSize is how many pvz StreamPVZList reads from the database at a
+// time; each page is sent before the next one is read.
+const streamPageSize = 100
+
+type PvzServer struct {
+	pvz_v1.UnimplementedPVZServiceServer
+	svc service.Service
+}
+
+func NewPvzServer(svc service.Service) *PvzServer {
+	return &PvzServer{
+		svc: svc,
+	}
+}
+
+func (s *PvzServer) GetPVZList(ctx context.Context, _ *pvz_v1.GetPVZListRequest) (*pvz_v1.GetPVZListResponse, error) {
+	pvzList, err := s.svc.GetPvzList(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	res := &pvz_v1.GetPVZListResponse{
+		Pvzs: make([]*pvz_v1.PVZ, 0, len(pvzList)),
+	}
+	for _, pvz := range pvzList {
+		res.Pvzs = append(res.Pvzs, toProtoPvz(pvz))
+	}
+	return res, nil
+}
+
+func (s *PvzServer) StreamPVZList(_ *pvz_v1.GetPVZListRequest, stream pvz_v1.PVZService_StreamPVZListServer) error {
+	var after *model.PvzCursor
+	for {
+		page, err := s.svc.GetPvzPage(stream.Context(), after, streamPageSize)
+		if err != nil {
+			return status.Error(codes.Internal, "internal server error")
+		}
+
+		for _, pvz := range page {
+			if err := stream.Send(toProtoPvz(pvz)); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < streamPageSize {
+			return nil
+		}
+		last := page[len(page)-1]
+		after = &model.PvzCursor{RegistrationDate: last.RegistrationDate, Id: last.Id}
+	}
+}
+
+func toProtoPvz(pvz model.Pvz) *pvz_v1.PVZ {
+	res := &pvz_v1.PVZ{
+		Id:               pvz.Id.String(),
+		RegistrationDate: timestamppb.New(pvz.RegistrationDate),
+		City:             string(pvz.City),
+		Address:          pvz.Address,
+		Latitude:         pvz.Latitude,
+		Longitude:        pvz.Longitude,
+		WorkingHours:     pvz.WorkingHours,
+		Active:           pvz.Active,
+	}
+	if pvz.DeactivatedAt != nil {
+		res.DeactivatedAt = timestamppb.New(*pvz.DeactivatedAt)
+	}
+	return res
+}
@@ -0,0 +1,157 @@
+package grpc_server
+
+import (
+	"avito2/internal/model"
+	"avito2/internal/pb/pvz_v1"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_GetPVZList(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx           = context.Background()
+		latitude      = 55.7558
+		longitude     = 37.6173
+		deactivatedAt = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+		pvzList       = []model.Pvz{
+			{
+				Id: uuid.New(), City: model.CityMoscow, RegistrationDate: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+				Address: "ул. Тверская, 1", Latitude: &latitude, Longitude: &longitude, WorkingHours: "09:00-21:00", Active: true,
+			},
+			{
+				Id: uuid.New(), City: model.CityKazan, RegistrationDate: time.Date(2025, 4, 2, 10, 0, 0, 0, time.UTC),
+				DeactivatedAt: &deactivatedAt,
+			},
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().GetPvzList(gomock.Any()).Return(pvzList, nil)
+
+		res, err := s.client.GetPVZList(ctx, &pvz_v1.GetPVZListRequest{})
+
+		require.NoError(t, err)
+		require.Len(t, res.Pvzs, len(pvzList))
+		for i, pvz := range pvzList {
+			assert.Equal(t, pvz.Id.String(), res.Pvzs[i].Id)
+			assert.Equal(t, string(pvz.City), res.Pvzs[i].City)
+			assert.True(t, pvz.RegistrationDate.Equal(res.Pvzs[i].RegistrationDate.AsTime()))
+			assert.Equal(t, pvz.Active, res.Pvzs[i].Active)
+		}
+
+		active := res.Pvzs[0]
+		assert.Equal(t, "ул. Тверская, 1", active.Address)
+		require.NotNil(t, active.Latitude)
+		require.NotNil(t, active.Longitude)
+		assert.Equal(t, latitude, active.GetLatitude())
+		assert.Equal(t, longitude, active.GetLongitude())
+		assert.Equal(t, "09:00-21:00", active.WorkingHours)
+		assert.Nil(t, active.DeactivatedAt)
+
+		deactivated := res.Pvzs[1]
+		assert.False(t, deactivated.Active)
+		require.NotNil(t, deactivated.DeactivatedAt)
+		assert.True(t, deactivatedAt.Equal(deactivated.DeactivatedAt.AsTime()))
+		assert.Nil(t, deactivated.Latitude)
+		assert.Empty(t, deactivated.Address)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().GetPvzList(gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := s.client.GetPVZList(ctx, &pvz_v1.GetPVZListRequest{})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func Test_StreamPVZList(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx       = context.Background()
+		firstPage = make([]model.Pvz, streamPageSize)
+		lastPage  = []model.Pvz{{Id: uuid.New(), City: model.CityKazan, RegistrationDate: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)}}
+	)
+	for i := range firstPage {
+		firstPage[i] = model.Pvz{Id: uuid.New(), City: model.CityMoscow, RegistrationDate: time.Date(2025, 4, 1, 10, i, 0, 0, time.UTC)}
+	}
+	last := firstPage[len(firstPage)-1]
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		firstReceived := make(chan struct{})
+		s.mockSvc.EXPECT().GetPvzPage(gomock.Any(), nil, int32(streamPageSize)).Return(firstPage, nil)
+		s.mockSvc.EXPECT().GetPvzPage(gomock.Any(), &model.PvzCursor{RegistrationDate: last.RegistrationDate, Id: last.Id}, int32(streamPageSize)).
+			DoAndReturn(func(context.Context, *model.PvzCursor, int32) ([]model.Pvz, error) {
+				// the first page reaches the client before the next one is read
+				select {
+				case <-firstReceived:
+				case <-time.After(5 * time.Second):
+					t.Error("first page was not sent before reading the next one")
+				}
+				return lastPage, nil
+			})
+
+		stream, err := s.client.StreamPVZList(ctx, &pvz_v1.GetPVZListRequest{})
+		require.NoError(t, err)
+
+		var received []*pvz_v1.PVZ
+		for {
+			pvz, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if len(received) == 0 {
+				close(firstReceived)
+			}
+			received = append(received, pvz)
+		}
+
+		pvzList := append(firstPage, lastPage...)
+		require.Len(t, received, len(pvzList))
+		for i, pvz := range pvzList {
+			assert.Equal(t, pvz.Id.String(), received[i].Id)
+			assert.Equal(t, string(pvz.City), received[i].City)
+		}
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().GetPvzPage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		stream, err := s.client.StreamPVZList(ctx, &pvz_v1.GetPVZListRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -0,0 +1,57 @@
+package grpc_server
+
+import (
+	"avito2/internal/pb/pvz_v1"
+	mock_service "avito2/internal/service/mocks"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+type grpcServerFixtures struct {
+	ctrl    *gomock.Controller
+	server  *grpc.Server
+	conn    *grpc.ClientConn
+	client  pvz_v1.PVZServiceClient
+	mockSvc *mock_service.MockService
+}
+
+func setUp(t *testing.T) grpcServerFixtures {
+	ctrl := gomock.NewController(t)
+	mockSvc := mock_service.NewMockService(ctrl)
+
+	lis := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	pvz_v1.RegisterPVZServiceServer(server, NewPvzServer(mockSvc))
+	go server.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	return grpcServerFixtures{
+		ctrl:    ctrl,
+		server:  server,
+		conn:    conn,
+		client:  pvz_v1.NewPVZServiceClient(conn),
+		mockSvc: mockSvc,
+	}
+}
+
+func (g *grpcServerFixtures) tearDown() {
+	g.conn.Close()
+	g.server.Stop()
+	g.ctrl.Finish()
+}
@@ -36,15 +36,71 @@ func Test_AddProduct(t *testing.T) {
 			Type:  model.ProductTypeClothes,
 			PvzId: "test",
 		}
+		pvzId = uuid.New()
 	)
 
+	t.Run("success with barcode only", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), pvzId, model.ProductType(""), "4601234567893").
+			Return(&model.Product{Type: model.ProductTypeShoes, Barcode: "4601234567893"}, nil)
+		body, err := json.Marshal(model.AddProductRequest{PvzId: pvzId.String(), Barcode: "4601234567893"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, employeeRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProduct(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("invalid barcode", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal(model.AddProductRequest{PvzId: pvzId.String(), Barcode: "test"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, employeeRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProduct(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_barcode", decodeErrorResponse(t, rec).Code)
+	})
+
+	t.Run("catalog item does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCatalogItemDoesNotExist)
+		body, err := json.Marshal(model.AddProductRequest{PvzId: pvzId.String(), Barcode: "4601234567893"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, employeeRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProduct(rec, req)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
@@ -79,6 +135,7 @@ func Test_AddProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), model.ProductType("test"), "").Return(nil, customErrors.ErrInvalidProductType)
 		body, err := json.Marshal(requestWithInvalidProductType)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
@@ -141,7 +198,7 @@ func Test_AddProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to add product"))
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to add product"))
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
@@ -151,6 +208,9 @@ func Test_AddProduct(t *testing.T) {
 
 		s.hm.AddProduct(rec, req)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "internal_error", res.Code)
+		assert.Equal(t, customErrors.ErrInternalServerError.Error(), res.Message)
 	})
 	t.Run("pvz does not exist", func(t *testing.T) {
 		t.Parallel()
@@ -158,7 +218,7 @@ func Test_AddProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
@@ -176,7 +236,7 @@ func Test_AddProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressDoesNotExist)
+		s.mockSvc.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressDoesNotExist)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewReader(body))
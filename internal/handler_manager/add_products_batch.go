@@ -0,0 +1,55 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+func (hm *HandlerManager) AddProductsBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	role := r.Context().Value(middleware.Role).(string)
+	if role != string(model.RoleEmployee) {
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
+		return
+	}
+
+	var req model.AddProductsBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	pvzId, err := uuid.Parse(req.PvzId)
+	if err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidPvzIdFormat)
+		return
+	}
+
+	if len(req.Products) == 0 || len(req.Products) > model.MaxProductBatchSize {
+		errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidProductBatch, map[string]any{
+			"max_items": model.MaxProductBatchSize,
+		}))
+		return
+	}
+
+	ctx := logger.With(r.Context(), "pvz_id", pvzId)
+	products, err := hm.svc.AddProducts(ctx, pvzId, req.Products)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(model.AddProductsBatchResponse{Products: products})
+}
@@ -0,0 +1,190 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddProductsBatch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pvzId    = uuid.New()
+		employee = string(model.RoleEmployee)
+		items    = []model.ProductBatchItem{
+			{Type: model.ProductTypeShoes},
+			{Type: model.ProductTypeClothes, Barcode: "4601234567893"},
+		}
+	)
+
+	newRequest := func(t *testing.T, method, role string, body any) *http.Request {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, "/products/batch", bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		products := []model.Product{
+			{Id: uuid.New(), Type: model.ProductTypeShoes},
+			{Id: uuid.New(), Type: model.ProductTypeClothes, Barcode: "4601234567893"},
+		}
+		s.mockSvc.EXPECT().AddProducts(gomock.Any(), pvzId, items).Return(products, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: pvzId.String(), Products: items}))
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var res model.AddProductsBatchResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, products, res.Products)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, string(model.RoleModerator), model.AddProductsBatchRequest{PvzId: pvzId.String(), Products: items}))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodGet, employee, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, "test"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_json", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("invalid pvz id", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: "test", Products: items}))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_pvz_id", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("empty batch", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: pvzId.String()}))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_product_batch", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("batch too large", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+		tooMany := make([]model.ProductBatchItem, model.MaxProductBatchSize+1)
+		for i := range tooMany {
+			tooMany[i] = model.ProductBatchItem{Type: model.ProductTypeShoes}
+		}
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: pvzId.String(), Products: tooMany}))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_product_batch", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("per item errors", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+		invalid := []model.ProductBatchItem{
+			{Type: model.ProductTypeShoes},
+			{Type: "test"},
+			{Type: model.ProductTypeClothes, Barcode: "12ab"},
+		}
+		itemErrors := []model.BatchItemError{
+			{Index: 1, Code: "invalid_product_type", Message: customErrors.ErrInvalidProductType.Error()},
+			{Index: 2, Code: "invalid_barcode", Message: customErrors.ErrInvalidBarcode.Error()},
+		}
+		s.mockSvc.EXPECT().AddProducts(gomock.Any(), pvzId, invalid).
+			Return(nil, customErrors.WithDetails(customErrors.ErrInvalidProductBatch, map[string]any{"items": itemErrors}))
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: pvzId.String(), Products: invalid}))
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var res struct {
+			Code    string `json:"code"`
+			Details struct {
+				Items []model.BatchItemError `json:"items"`
+			} `json:"details"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "invalid_product_batch", res.Code)
+		assert.Equal(t, itemErrors, res.Details.Items)
+	})
+	t.Run("reception in progress does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().AddProducts(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressDoesNotExist)
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: pvzId.String(), Products: items}))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "reception_in_progress_not_found", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().AddProducts(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		rec := httptest.NewRecorder()
+
+		s.hm.AddProductsBatch(rec, newRequest(t, http.MethodPost, employee, model.AddProductsBatchRequest{PvzId: pvzId.String(), Products: items}))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,120 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CancelReception(t *testing.T) {
+	t.Parallel()
+
+	var (
+		receptionId = uuid.New()
+		userId      = uuid.NewString()
+		employee    = string(model.RoleEmployee)
+		moderator   = string(model.RoleModerator)
+	)
+
+	newRequest := func(method, id, role string) *http.Request {
+		req := httptest.NewRequest(method, "/receptions/"+id+"/cancel", nil)
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		ctx = context.WithValue(ctx, middleware.UserId, userId)
+		return req.WithContext(ctx)
+	}
+	serve := func(s handlerManagerFixtures, req *http.Request) *httptest.ResponseRecorder {
+		r := mux.NewRouter()
+		r.Handle("/receptions/{receptionId}/cancel", http.HandlerFunc(s.hm.CancelReception))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CancelReception(gomock.Any(), receptionId, model.Actor{Id: userId, Role: model.RoleEmployee}).
+			Return(&model.Reception{Id: receptionId, Status: model.ReceptionStatusCancelled}, nil)
+
+		rec := serve(s, newRequest(http.MethodPost, receptionId.String(), employee))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodPost, receptionId.String(), moderator))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid reception id", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodPost, "test", employee))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_reception_id", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodGet, receptionId.String(), employee))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("reception does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CancelReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionDoesNotExist)
+
+		rec := serve(s, newRequest(http.MethodPost, receptionId.String(), employee))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+	t.Run("transition not allowed", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CancelReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrInvalidReceptionTransition)
+
+		rec := serve(s, newRequest(http.MethodPost, receptionId.String(), employee))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "invalid_reception_transition", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CancelReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		rec := serve(s, newRequest(http.MethodPost, receptionId.String(), employee))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
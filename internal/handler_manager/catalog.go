@@ -0,0 +1,69 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (hm *HandlerManager) CreateCatalogItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	role := r.Context().Value(middleware.Role).(string)
+	if role != string(model.RoleModerator) {
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
+		return
+	}
+
+	var req model.CatalogItem
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	if field := req.InvalidField(); field != "" {
+		errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidCatalogItem, map[string]any{
+			"field": field,
+		}))
+		return
+	}
+
+	ctx := logger.With(r.Context(), "barcode", req.Barcode)
+	res, err := hm.svc.CreateCatalogItem(ctx, req)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(res)
+}
+
+// GetCatalogItem returns the catalog item whose barcode or SKU is in the path.
+func (hm *HandlerManager) GetCatalogItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	code := mux.Vars(r)["code"]
+	ctx := logger.With(r.Context(), "catalog_code", code)
+	res, err := hm.svc.GetCatalogItem(ctx, code)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
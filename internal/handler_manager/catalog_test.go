@@ -0,0 +1,161 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateCatalogItem(t *testing.T) {
+	t.Parallel()
+
+	var (
+		moderator = string(model.RoleModerator)
+		item      = model.CatalogItem{Barcode: "4601234567893", Sku: "SHOE-42", Name: "Кеды", Type: model.ProductTypeShoes, WeightGrams: 800, LengthMm: 320, WidthMm: 200, HeightMm: 120}
+	)
+
+	newRequest := func(t *testing.T, method, role string, body any) *http.Request {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, "/catalog", bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CreateCatalogItem(gomock.Any(), item).Return(&item, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.CreateCatalogItem(rec, newRequest(t, http.MethodPost, moderator, item))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.CreateCatalogItem(rec, newRequest(t, http.MethodPost, string(model.RoleEmployee), item))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.CreateCatalogItem(rec, newRequest(t, http.MethodGet, moderator, item))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.CreateCatalogItem(rec, newRequest(t, http.MethodPost, moderator, "test"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid field", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+		invalid := item
+		invalid.WeightGrams = 0
+
+		s.hm.CreateCatalogItem(rec, newRequest(t, http.MethodPost, moderator, invalid))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "invalid_catalog_item", res.Code)
+		assert.Equal(t, "weight_grams", res.Details["field"])
+	})
+	t.Run("already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CreateCatalogItem(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCatalogItemAlreadyExists)
+		rec := httptest.NewRecorder()
+
+		s.hm.CreateCatalogItem(rec, newRequest(t, http.MethodPost, moderator, item))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func Test_GetCatalogItem(t *testing.T) {
+	t.Parallel()
+
+	serve := func(s handlerManagerFixtures, method, code string) *httptest.ResponseRecorder {
+		r := mux.NewRouter()
+		r.Handle("/catalog/{code}", http.HandlerFunc(s.hm.GetCatalogItem))
+		req := httptest.NewRequest(method, "/catalog/"+code, nil)
+		ctx := context.WithValue(req.Context(), middleware.Role, string(model.RoleEmployee))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		item := &model.CatalogItem{Barcode: "4601234567893", Sku: "SHOE-42", Type: model.ProductTypeShoes}
+		s.mockSvc.EXPECT().GetCatalogItem(gomock.Any(), "SHOE-42").Return(item, nil)
+
+		rec := serve(s, http.MethodGet, "SHOE-42")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res model.CatalogItem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, item.Barcode, res.Barcode)
+	})
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().GetCatalogItem(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCatalogItemDoesNotExist)
+
+		rec := serve(s, http.MethodGet, "test")
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "catalog_item_not_found", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, http.MethodPost, "test")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,102 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Cities lists the city registry for any role and adds cities for moderators.
+func (hm *HandlerManager) Cities(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		res, err := hm.svc.ListCities(r.Context())
+		if err != nil {
+			errors.WriteHttpError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(res)
+	case http.MethodPost:
+		role := r.Context().Value(middleware.Role).(string)
+		if role != string(model.RoleModerator) {
+			errors.WriteHttpError(w, errors.ErrAccessDenied)
+			return
+		}
+
+		var req model.CreateCityRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			errors.WriteHttpError(w, errors.ErrInvalidJson)
+			return
+		}
+
+		city := model.CityInfo{
+			Name:     req.Name,
+			Region:   req.Region,
+			Timezone: req.Timezone,
+			Active:   req.Active == nil || *req.Active,
+		}
+		ctx := logger.With(r.Context(), "city", req.Name)
+		res, err := hm.svc.CreateCity(ctx, city)
+		if err != nil {
+			errors.WriteHttpError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	default:
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+	}
+}
+
+// City updates or deletes the city named in the path. Only moderators may
+// change the registry.
+func (hm *HandlerManager) City(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch && r.Method != http.MethodDelete {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	role := r.Context().Value(middleware.Role).(string)
+	if role != string(model.RoleModerator) {
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
+		return
+	}
+
+	name := model.City(mux.Vars(r)["name"])
+	ctx := logger.With(r.Context(), "city", name)
+
+	if r.Method == http.MethodDelete {
+		if err := hm.svc.DeleteCity(ctx, name); err != nil {
+			errors.WriteHttpError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var req model.UpdateCityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	res, err := hm.svc.UpdateCity(ctx, name, req)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
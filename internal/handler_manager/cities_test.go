@@ -0,0 +1,226 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cities(t *testing.T) {
+	t.Parallel()
+
+	var (
+		moderator = string(model.RoleModerator)
+		employee  = string(model.RoleEmployee)
+		request   = model.CreateCityRequest{Name: "Новосибирск", Region: "Новосибирская область", Timezone: "Asia/Novosibirsk"}
+	)
+
+	newRequest := func(t *testing.T, method, role string, body any) *http.Request {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, "/cities", bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		cities := []model.CityInfo{{Name: model.CityMoscow, Region: "Москва", Timezone: "Europe/Moscow", Active: true}}
+		s.mockSvc.EXPECT().ListCities(gomock.Any()).Return(cities, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodGet, employee, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res []model.CityInfo
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, cities, res)
+	})
+	t.Run("create active by default", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		city := model.CityInfo{Name: request.Name, Region: request.Region, Timezone: request.Timezone, Active: true}
+		s.mockSvc.EXPECT().CreateCity(gomock.Any(), city).Return(&city, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodPost, moderator, request))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+	t.Run("create inactive", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		inactive := false
+		req := request
+		req.Active = &inactive
+		s.mockSvc.EXPECT().CreateCity(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, city model.CityInfo) (*model.CityInfo, error) {
+			assert.False(t, city.Active)
+			return &city, nil
+		})
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodPost, moderator, req))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+	t.Run("create access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodPost, employee, request))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("create invalid field", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CreateCity(gomock.Any(), gomock.Any()).
+			Return(nil, customErrors.WithDetails(customErrors.ErrInvalidCity, map[string]any{"field": "timezone"}))
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodPost, moderator, request))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "invalid_city", res.Code)
+		assert.Equal(t, "timezone", res.Details["field"])
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodPost, moderator, "test"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Cities(rec, newRequest(t, http.MethodDelete, moderator, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func Test_City(t *testing.T) {
+	t.Parallel()
+
+	var (
+		moderator = string(model.RoleModerator)
+		employee  = string(model.RoleEmployee)
+	)
+
+	serve := func(t *testing.T, s handlerManagerFixtures, method, name, role string, body any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		r := mux.NewRouter()
+		r.Handle("/cities/{name}", http.HandlerFunc(s.hm.City))
+		req := httptest.NewRequest(method, "/cities/"+url.PathEscape(name), bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	t.Run("deactivate", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		inactive := false
+		s.mockSvc.EXPECT().UpdateCity(gomock.Any(), model.CityKazan, model.UpdateCityRequest{Active: &inactive}).
+			Return(&model.CityInfo{Name: model.CityKazan}, nil)
+
+		rec := serve(t, s, http.MethodPatch, string(model.CityKazan), moderator, map[string]any{"active": false})
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res model.CityInfo
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.False(t, res.Active)
+	})
+	t.Run("update not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().UpdateCity(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCityDoesNotExist)
+
+		rec := serve(t, s, http.MethodPatch, "Тверь", moderator, map[string]any{"region": "Тверская область"})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteCity(gomock.Any(), model.City("Тверь")).Return(nil)
+
+		rec := serve(t, s, http.MethodDelete, "Тверь", moderator, nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("delete in use", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteCity(gomock.Any(), gomock.Any()).Return(customErrors.ErrCityInUse)
+
+		rec := serve(t, s, http.MethodDelete, string(model.CityMoscow), moderator, nil)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "city_in_use", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodDelete, "Тверь", employee, nil)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodPut, "Тверь", moderator, nil)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
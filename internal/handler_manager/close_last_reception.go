@@ -2,6 +2,7 @@ package handler_manager
 
 import (
 	"avito2/internal/errors"
+	"avito2/internal/logger"
 	"avito2/internal/middleware"
 	"avito2/internal/model"
 	"encoding/json"
@@ -13,13 +14,13 @@ import (
 
 func (hm *HandlerManager) CloseLastReception(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, errors.ErrInvalidHtppMethod.Error(), http.StatusMethodNotAllowed)
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
 		return
 	}
 
 	role := r.Context().Value(middleware.Role).(string)
 	if role != string(model.RoleEmployee) {
-		http.Error(w, errors.ErrAccessDenied.Error(), http.StatusForbidden)
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
 		return
 	}
 
@@ -28,27 +29,18 @@ func (hm *HandlerManager) CloseLastReception(w http.ResponseWriter, r *http.Requ
 
 	uuid, err := uuid.Parse(pvzId)
 	if err != nil {
-		http.Error(w, errors.ErrInvalidPvzIdFormat.Error(), http.StatusBadRequest)
+		errors.WriteHttpError(w, errors.ErrInvalidPvzIdFormat)
 		return
 	}
 
-	ctx := r.Context()
-	res, err := hm.svc.CloseLastReception(ctx, uuid)
-
-	switch err {
-	case nil:
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(res)
-		return
-	case errors.ErrPvzDoesNotExist:
-		http.Error(w, errors.ErrPvzDoesNotExist.Error(), http.StatusBadRequest)
-		return
-	case errors.ErrReceptionInProgressDoesNotExist:
-		http.Error(w, errors.ErrReceptionInProgressDoesNotExist.Error(), http.StatusBadRequest)
-		return
-	default:
-		http.Error(w, errors.ErrInternalServerError.Error(), http.StatusInternalServerError)
+	ctx := logger.With(r.Context(), "pvz_id", uuid)
+	res, err := hm.svc.CloseLastReception(ctx, uuid, actorFromContext(ctx))
+	if err != nil {
+		errors.WriteHttpError(w, err)
 		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
 }
@@ -32,7 +32,7 @@ func Test_CloseLastReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/close_last_reception", http.HandlerFunc(s.hm.CloseLastReception))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/close_last_reception", nil)
@@ -99,7 +99,7 @@ func Test_CloseLastReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to close last reception"))
+		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to close last reception"))
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/close_last_reception", http.HandlerFunc(s.hm.CloseLastReception))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/close_last_reception", nil)
@@ -116,7 +116,7 @@ func Test_CloseLastReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
+		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/close_last_reception", http.HandlerFunc(s.hm.CloseLastReception))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/close_last_reception", nil)
@@ -134,7 +134,7 @@ func Test_CloseLastReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressDoesNotExist)
+		s.mockSvc.EXPECT().CloseLastReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressDoesNotExist)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/close_last_reception", http.HandlerFunc(s.hm.CloseLastReception))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/close_last_reception", nil)
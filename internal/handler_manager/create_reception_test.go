@@ -38,7 +38,7 @@ func Test_CreateReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(body))
@@ -118,7 +118,7 @@ func Test_CreateReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to create reception"))
+		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to create reception"))
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(body))
@@ -135,7 +135,7 @@ func Test_CreateReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
+		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(body))
@@ -153,7 +153,7 @@ func Test_CreateReception(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressAlreadyExists)
+		s.mockSvc.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressAlreadyExists)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(body))
@@ -163,5 +163,6 @@ func Test_CreateReception(t *testing.T) {
 
 		s.hm.CreateReception(rec, req)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "reception_in_progress_already_exists", decodeErrorResponse(t, rec).Code)
 	})
 }
@@ -2,6 +2,7 @@ package handler_manager
 
 import (
 	"avito2/internal/errors"
+	"avito2/internal/logger"
 	"avito2/internal/middleware"
 	"avito2/internal/model"
 	"net/http"
@@ -12,13 +13,13 @@ import (
 
 func (hm *HandlerManager) DeleteLastProduct(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, errors.ErrInvalidHtppMethod.Error(), http.StatusMethodNotAllowed)
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
 		return
 	}
 
 	role := r.Context().Value(middleware.Role).(string)
 	if role != string(model.RoleEmployee) {
-		http.Error(w, errors.ErrAccessDenied.Error(), http.StatusForbidden)
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
 		return
 	}
 
@@ -27,28 +28,15 @@ func (hm *HandlerManager) DeleteLastProduct(w http.ResponseWriter, r *http.Reque
 
 	uuid, err := uuid.Parse(pvzId)
 	if err != nil {
-		http.Error(w, errors.ErrInvalidPvzIdFormat.Error(), http.StatusBadRequest)
+		errors.WriteHttpError(w, errors.ErrInvalidPvzIdFormat)
 		return
 	}
 
-	ctx := r.Context()
-	err = hm.svc.DeleteLastProduct(ctx, uuid)
-
-	switch err {
-	case nil:
-		w.WriteHeader(http.StatusOK)
-		return
-	case errors.ErrReceptionInProgressDoesNotExist:
-		http.Error(w, errors.ErrReceptionInProgressDoesNotExist.Error(), http.StatusBadRequest)
-		return
-	case errors.ErrPvzDoesNotExist:
-		http.Error(w, errors.ErrPvzDoesNotExist.Error(), http.StatusBadRequest)
-		return
-	case errors.ErrNoProductToDelete:
-		http.Error(w, errors.ErrNoProductToDelete.Error(), http.StatusBadRequest)
-		return
-	default:
-		http.Error(w, errors.ErrInternalServerError.Error(), http.StatusInternalServerError)
+	ctx := logger.With(r.Context(), "pvz_id", uuid)
+	if err := hm.svc.DeleteLastProduct(ctx, uuid, actorFromContext(ctx)); err != nil {
+		errors.WriteHttpError(w, err)
 		return
 	}
+
+	w.WriteHeader(http.StatusOK)
 }
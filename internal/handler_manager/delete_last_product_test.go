@@ -32,7 +32,7 @@ func Test_DeleteLastProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any()).Return(nil)
+		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/delete_last_product", http.HandlerFunc(s.hm.DeleteLastProduct))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/delete_last_product", nil)
@@ -98,7 +98,7 @@ func Test_DeleteLastProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any()).Return(errors.New("failed to delete last product"))
+		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed to delete last product"))
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/delete_last_product", http.HandlerFunc(s.hm.DeleteLastProduct))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/delete_last_product", nil)
@@ -115,7 +115,7 @@ func Test_DeleteLastProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any()).Return(customErrors.ErrPvzDoesNotExist)
+		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(customErrors.ErrPvzDoesNotExist)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/delete_last_product", http.HandlerFunc(s.hm.DeleteLastProduct))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/delete_last_product", nil)
@@ -133,7 +133,7 @@ func Test_DeleteLastProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any()).Return(customErrors.ErrReceptionInProgressDoesNotExist)
+		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(customErrors.ErrReceptionInProgressDoesNotExist)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/delete_last_product", http.HandlerFunc(s.hm.DeleteLastProduct))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/delete_last_product", nil)
@@ -151,7 +151,7 @@ func Test_DeleteLastProduct(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any()).Return(customErrors.ErrNoProductToDelete)
+		s.mockSvc.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(customErrors.ErrNoProductToDelete)
 		r := mux.NewRouter()
 		r.Handle("/pvz/{pvzId}/delete_last_product", http.HandlerFunc(s.hm.DeleteLastProduct))
 		req := httptest.NewRequest(http.MethodPost, "/pvz/"+pvzId+"/delete_last_product", nil)
@@ -0,0 +1,46 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func (hm *HandlerManager) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	role := r.Context().Value(middleware.Role).(string)
+	if role != string(model.RoleEmployee) {
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
+		return
+	}
+
+	vars := mux.Vars(r)
+	receptionId, err := uuid.Parse(vars["receptionId"])
+	if err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidReceptionIdFormat)
+		return
+	}
+
+	productId, err := uuid.Parse(vars["productId"])
+	if err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidProductIdFormat)
+		return
+	}
+
+	ctx := logger.With(r.Context(), "reception_id", receptionId, "product_id", productId)
+	if err := hm.svc.DeleteProduct(ctx, receptionId, productId, actorFromContext(ctx)); err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,132 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DeleteProduct(t *testing.T) {
+	t.Parallel()
+
+	var (
+		receptionId = uuid.New()
+		productId   = uuid.New()
+		userId      = uuid.NewString()
+		employee    = string(model.RoleEmployee)
+		moderator   = string(model.RoleModerator)
+	)
+
+	newRequest := func(method, receptionId, productId, role string) *http.Request {
+		req := httptest.NewRequest(method, "/receptions/"+receptionId+"/products/"+productId, nil)
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		ctx = context.WithValue(ctx, middleware.UserId, userId)
+		return req.WithContext(ctx)
+	}
+	serve := func(s handlerManagerFixtures, req *http.Request) *httptest.ResponseRecorder {
+		r := mux.NewRouter()
+		r.Handle("/receptions/{receptionId}/products/{productId}", http.HandlerFunc(s.hm.DeleteProduct))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteProduct(gomock.Any(), receptionId, productId, model.Actor{Id: userId, Role: model.RoleEmployee}).Return(nil)
+
+		rec := serve(s, newRequest(http.MethodDelete, receptionId.String(), productId.String(), employee))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodDelete, receptionId.String(), productId.String(), moderator))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid reception id", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodDelete, "test", productId.String(), employee))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_reception_id", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("invalid product id", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodDelete, receptionId.String(), "test", employee))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_product_id", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(s, newRequest(http.MethodPost, receptionId.String(), productId.String(), employee))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("product does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(customErrors.ErrProductDoesNotExist)
+
+		rec := serve(s, newRequest(http.MethodDelete, receptionId.String(), productId.String(), employee))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "product_not_found", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("reception not in progress", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(customErrors.ErrReceptionNotInProgress)
+
+		rec := serve(s, newRequest(http.MethodDelete, receptionId.String(), productId.String(), employee))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "reception_not_in_progress", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		rec := serve(s, newRequest(http.MethodDelete, receptionId.String(), productId.String(), employee))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
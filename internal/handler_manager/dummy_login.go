@@ -5,32 +5,26 @@ import (
 	"avito2/internal/model"
 	"encoding/json"
 	"net/http"
+
+	"github.com/google/uuid"
 )
 
 func (hm *HandlerManager) DummyLogin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, errors.ErrInvalidHtppMethod.Error(), http.StatusMethodNotAllowed)
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
 		return
 	}
 
 	var req model.DummyLoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, errors.ErrInvalidJson.Error(), http.StatusBadRequest)
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
 		return
 	}
 
 	if !req.Role.IsValid() {
-		http.Error(w, "invalid role", http.StatusBadRequest)
-		return
-	}
-
-	token, err := hm.jwtGen.GenerateJWT(string(req.Role))
-	if err != nil {
-		http.Error(w, errors.ErrInternalServerError.Error(), http.StatusInternalServerError)
+		errors.WriteHttpError(w, errors.ErrInvalidRole)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"token": token})
+	hm.writeTokens(r.Context(), w, uuid.NewString(), req.Role)
 }
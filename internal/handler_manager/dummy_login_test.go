@@ -28,7 +28,8 @@ func Test_DummyLogin(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any()).Return("token", nil)
+		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any(), gomock.Any()).Return("token", nil)
+		s.mockUserSvc.EXPECT().IssueRefreshToken(gomock.Any(), gomock.Any(), model.RoleEmployee).Return("refresh", nil)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/dummyLogin", bytes.NewReader(body))
@@ -85,7 +86,7 @@ func Test_DummyLogin(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any()).Return("", generateJwtErr)
+		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any(), gomock.Any()).Return("", generateJwtErr)
 		body, err := json.Marshal(request)
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/dummyLogin", bytes.NewReader(body))
@@ -1,18 +1,38 @@
 package handler_manager
 
 import (
+	"avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
 	"avito2/internal/service"
 	"avito2/internal/utils"
+	"context"
 )
 
 type HandlerManager struct {
-	jwtGen utils.JWTGenerator
-	svc    service.Service
+	jwtGen  utils.JWTGenerator
+	svc     service.Service
+	userSvc service.UserService
 }
 
-func NewHandlerManager(svc service.Service, jwtGen utils.JWTGenerator) *HandlerManager {
+func NewHandlerManager(svc service.Service, userSvc service.UserService, jwtGen utils.JWTGenerator) *HandlerManager {
 	return &HandlerManager{
-		svc:    svc,
-		jwtGen: jwtGen,
+		svc:     svc,
+		userSvc: userSvc,
+		jwtGen:  jwtGen,
 	}
 }
+
+func invalidQueryParam(param, reason string) error {
+	return errors.WithDetails(errors.ErrInvalidQueryParam, map[string]any{
+		"param":  param,
+		"reason": reason,
+	})
+}
+
+// actorFromContext returns the caller authenticated by middleware.AuthMiddleware.
+func actorFromContext(ctx context.Context) model.Actor {
+	role, _ := ctx.Value(middleware.Role).(string)
+	userId, _ := ctx.Value(middleware.UserId).(string)
+	return model.Actor{Id: userId, Role: model.Role(role)}
+}
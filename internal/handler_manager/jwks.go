@@ -0,0 +1,21 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"encoding/json"
+	"net/http"
+)
+
+// JWKS publishes the public keys access tokens can be verified with. Clients
+// may cache the set briefly; a key is published before it signs anything.
+func (hm *HandlerManager) JWKS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hm.jwtGen.JWKS())
+}
@@ -0,0 +1,45 @@
+package handler_manager
+
+import (
+	"avito2/internal/utils"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_JWKS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		jwks := utils.JWKS{Keys: []utils.JWK{{Kty: "OKP", Kid: "2025-05", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "test"}}}
+		s.mockJWTGen.EXPECT().JWKS().Return(jwks)
+		rec := httptest.NewRecorder()
+
+		s.hm.JWKS(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("Cache-Control"))
+		var res utils.JWKS
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, jwks, res)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.JWKS(rec, httptest.NewRequest(http.MethodPost, "/.well-known/jwks.json", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
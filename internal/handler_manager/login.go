@@ -0,0 +1,30 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+)
+
+func (hm *HandlerManager) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	var req model.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := hm.userSvc.Login(ctx, req.Email, req.Password)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	hm.writeTokens(ctx, w, user.Id.String(), user.Role)
+}
@@ -0,0 +1,151 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Login(t *testing.T) {
+	t.Parallel()
+
+	var (
+		request = model.LoginRequest{
+			Email:    "employee@example.com",
+			Password: "password",
+		}
+		user = &model.User{
+			Id:    uuid.New(),
+			Email: "employee@example.com",
+			Role:  model.RoleEmployee,
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Login(gomock.Any(), request.Email, request.Password).Return(user, nil)
+		s.mockJWTGen.EXPECT().GenerateJWT(user.Id.String(), string(user.Role)).Return("token", nil)
+		s.mockUserSvc.EXPECT().IssueRefreshToken(gomock.Any(), user.Id.String(), user.Role).Return("refresh", nil)
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		res := map[string]string{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "token", res["token"])
+		assert.Equal(t, "refresh", res["refresh_token"])
+	})
+	t.Run("invalid credentials", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrInvalidCredentials)
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("failed to generate jwt", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any(), gomock.Any()).Return("", errors.New("failed to generate jwt"))
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("failed to issue refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any(), gomock.Any()).Return("token", nil)
+		s.mockUserSvc.EXPECT().IssueRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("db error"))
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		req := httptest.NewRequest(http.MethodGet, "/login", nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal("bad request")
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Login(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
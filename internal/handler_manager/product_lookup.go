@@ -0,0 +1,34 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+)
+
+// ProductLookup finds the receptions and pvz a barcode was accepted into.
+func (hm *HandlerManager) ProductLookup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	barcode := r.URL.Query().Get("barcode")
+	if !model.IsValidBarcode(barcode) {
+		errors.WriteHttpError(w, invalidQueryParam("barcode", "must be 8 to 14 digits"))
+		return
+	}
+
+	ctx := logger.With(r.Context(), "barcode", barcode)
+	res, err := hm.svc.FindProductsByBarcode(ctx, barcode)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,79 @@
+package handler_manager
+
+import (
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProductLookup(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(method, query string) *http.Request {
+		req := httptest.NewRequest(method, "/products/lookup"+query, nil)
+		ctx := context.WithValue(req.Context(), middleware.Role, string(model.RoleModerator))
+		return req.WithContext(ctx)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		locations := []model.ProductLocation{{ProductId: uuid.New(), Barcode: "4601234567893", PvzId: uuid.New(), City: model.CityKazan}}
+		s.mockSvc.EXPECT().FindProductsByBarcode(gomock.Any(), "4601234567893").Return(locations, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductLookup(rec, newRequest(http.MethodGet, "?barcode=4601234567893"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res []model.ProductLocation
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, locations[0].PvzId, res[0].PvzId)
+	})
+	t.Run("invalid barcode", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductLookup(rec, newRequest(http.MethodGet, "?barcode=test"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "barcode", decodeErrorResponse(t, rec).Details["param"])
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductLookup(rec, newRequest(http.MethodPost, "?barcode=4601234567893"))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().FindProductsByBarcode(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductLookup(rec, newRequest(http.MethodGet, "?barcode=4601234567893"))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,96 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ProductTypes lists product types for any role and creates them for moderators.
+func (hm *HandlerManager) ProductTypes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		res, err := hm.svc.ListProductTypes(r.Context())
+		if err != nil {
+			errors.WriteHttpError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(res)
+	case http.MethodPost:
+		role := r.Context().Value(middleware.Role).(string)
+		if role != string(model.RoleModerator) {
+			errors.WriteHttpError(w, errors.ErrAccessDenied)
+			return
+		}
+
+		var req model.ProductTypeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			errors.WriteHttpError(w, errors.ErrInvalidJson)
+			return
+		}
+
+		ctx := logger.With(r.Context(), "product_type", req.Name)
+		res, err := hm.svc.CreateProductType(ctx, req.Name)
+		if err != nil {
+			errors.WriteHttpError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(res)
+	default:
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+	}
+}
+
+// ProductType renames or deletes the product type named in the path. Only
+// moderators may change product types.
+func (hm *HandlerManager) ProductType(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch && r.Method != http.MethodDelete {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	role := r.Context().Value(middleware.Role).(string)
+	if role != string(model.RoleModerator) {
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
+		return
+	}
+
+	name := model.ProductType(mux.Vars(r)["name"])
+	ctx := logger.With(r.Context(), "product_type", name)
+
+	if r.Method == http.MethodDelete {
+		if err := hm.svc.DeleteProductType(ctx, name); err != nil {
+			errors.WriteHttpError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var req model.ProductTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	res, err := hm.svc.RenameProductType(ctx, name, req.Name)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,187 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProductTypes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		moderator = string(model.RoleModerator)
+		employee  = string(model.RoleEmployee)
+	)
+
+	newRequest := func(t *testing.T, method, role string, body any) *http.Request {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, "/product_types", bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		types := []model.ProductTypeInfo{{Name: model.ProductTypeShoes}}
+		s.mockSvc.EXPECT().ListProductTypes(gomock.Any()).Return(types, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductTypes(rec, newRequest(t, http.MethodGet, employee, nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res []model.ProductTypeInfo
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, types[0].Name, res[0].Name)
+	})
+	t.Run("create", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CreateProductType(gomock.Any(), model.ProductType("книги")).Return(&model.ProductTypeInfo{Name: "книги"}, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductTypes(rec, newRequest(t, http.MethodPost, moderator, model.ProductTypeRequest{Name: "книги"}))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+	t.Run("create access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductTypes(rec, newRequest(t, http.MethodPost, employee, model.ProductTypeRequest{Name: "книги"}))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("create already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().CreateProductType(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrProductTypeAlreadyExists)
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductTypes(rec, newRequest(t, http.MethodPost, moderator, model.ProductTypeRequest{Name: model.ProductTypeShoes}))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductTypes(rec, newRequest(t, http.MethodPost, moderator, "test"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.ProductTypes(rec, newRequest(t, http.MethodDelete, moderator, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func Test_ProductType(t *testing.T) {
+	t.Parallel()
+
+	var (
+		moderator = string(model.RoleModerator)
+		employee  = string(model.RoleEmployee)
+	)
+
+	serve := func(t *testing.T, s handlerManagerFixtures, method, name, role string, body any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		r := mux.NewRouter()
+		r.Handle("/product_types/{name}", http.HandlerFunc(s.hm.ProductType))
+		req := httptest.NewRequest(method, "/product_types/"+url.PathEscape(name), bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().RenameProductType(gomock.Any(), model.ProductTypeShoes, model.ProductType("обувь и аксессуары")).
+			Return(&model.ProductTypeInfo{Name: "обувь и аксессуары"}, nil)
+
+		rec := serve(t, s, http.MethodPatch, string(model.ProductTypeShoes), moderator, model.ProductTypeRequest{Name: "обувь и аксессуары"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteProductType(gomock.Any(), model.ProductType("книги")).Return(nil)
+
+		rec := serve(t, s, http.MethodDelete, "книги", moderator, nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("delete in use", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().DeleteProductType(gomock.Any(), gomock.Any()).Return(customErrors.ErrProductTypeInUse)
+
+		rec := serve(t, s, http.MethodDelete, string(model.ProductTypeShoes), moderator, nil)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "product_type_in_use", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodDelete, "книги", employee, nil)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodPut, "книги", moderator, nil)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -15,26 +15,26 @@ func (hm *HandlerManager) Pvz(w http.ResponseWriter, r *http.Request) {
 	case http.MethodPost:
 		role := r.Context().Value(middleware.Role).(string)
 		if role != string(model.RoleModerator) {
-			http.Error(w, errors.ErrAccessDenied.Error(), http.StatusForbidden)
+			errors.WriteHttpError(w, errors.ErrAccessDenied)
 			return
 		}
 
 		var req model.CreatePvzRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, errors.ErrInvalidJson.Error(), http.StatusBadRequest)
+			errors.WriteHttpError(w, errors.ErrInvalidJson)
 			return
 		}
 
-		if !req.City.IsValid() {
-			http.Error(w, "invalid city", http.StatusBadRequest)
+		if !req.City.IsWellFormed() {
+			errors.WriteHttpError(w, errors.ErrInvalidCity)
 			return
 		}
 
 		ctx := r.Context()
-		res, err := hm.svc.CreatePvz(ctx, req.City)
+		res, err := hm.svc.CreatePvz(ctx, req)
 
 		if err != nil {
-			http.Error(w, errors.ErrInternalServerError.Error(), http.StatusInternalServerError)
+			errors.WriteHttpError(w, err)
 			return
 		}
 
@@ -51,7 +51,7 @@ func (hm *HandlerManager) Pvz(w http.ResponseWriter, r *http.Request) {
 			var err error
 			startDate, err = time.Parse(time.DateTime, startDateStr)
 			if err != nil {
-				http.Error(w, "invalid start date format", http.StatusBadRequest)
+				errors.WriteHttpError(w, invalidQueryParam("startDate", "invalid date format"))
 				return
 			}
 		}
@@ -62,13 +62,13 @@ func (hm *HandlerManager) Pvz(w http.ResponseWriter, r *http.Request) {
 			var err error
 			endDate, err = time.Parse(time.DateTime, endDateStr)
 			if err != nil {
-				http.Error(w, "invalid end date format", http.StatusBadRequest)
+				errors.WriteHttpError(w, invalidQueryParam("endDate", "invalid date format"))
 				return
 			}
 		}
 
 		if endDate.Before(startDate) {
-			http.Error(w, "end date must be later than start date", http.StatusBadRequest)
+			errors.WriteHttpError(w, invalidQueryParam("endDate", "must be later than start date"))
 			return
 		}
 
@@ -78,12 +78,12 @@ func (hm *HandlerManager) Pvz(w http.ResponseWriter, r *http.Request) {
 			var err error
 			pageNumber, err = strconv.Atoi(page)
 			if err != nil {
-				http.Error(w, "page value must be integer", http.StatusBadRequest)
+				errors.WriteHttpError(w, invalidQueryParam("page", "must be integer"))
 				return
 			}
 
 			if pageNumber < 1 {
-				http.Error(w, "page value must be greater than 0", http.StatusBadRequest)
+				errors.WriteHttpError(w, invalidQueryParam("page", "must be greater than 0"))
 				return
 			}
 		}
@@ -94,21 +94,79 @@ func (hm *HandlerManager) Pvz(w http.ResponseWriter, r *http.Request) {
 			var err error
 			lim, err = strconv.Atoi(limit)
 			if err != nil {
-				http.Error(w, "limit value must be integer", http.StatusBadRequest)
+				errors.WriteHttpError(w, invalidQueryParam("limit", "must be integer"))
 				return
 			}
 
 			if lim < 1 || lim > 30 {
-				http.Error(w, "limit value must be greater than 0 or less than or equal to 30", http.StatusBadRequest)
+				errors.WriteHttpError(w, invalidQueryParam("limit", "must be greater than 0 and less than or equal to 30"))
 				return
 			}
 		}
 
+		includeEmptyStr := queryParams.Get("includeEmpty")
+		includeEmpty := false
+		if includeEmptyStr != "" {
+			var err error
+			includeEmpty, err = strconv.ParseBool(includeEmptyStr)
+			if err != nil {
+				errors.WriteHttpError(w, invalidQueryParam("includeEmpty", "must be boolean"))
+				return
+			}
+		}
+
+		cursorStr := queryParams.Get("cursor")
+		var cursor *model.PvzCursor
+		if cursorStr != "" {
+			if page != "" {
+				errors.WriteHttpError(w, invalidQueryParam("cursor", "can not be used together with page"))
+				return
+			}
+
+			var err error
+			cursor, err = model.DecodePvzCursor(cursorStr)
+			if err != nil {
+				errors.WriteHttpError(w, invalidQueryParam("cursor", "invalid cursor"))
+				return
+			}
+		}
+
+		status := model.ReceptionStatus(queryParams.Get("status"))
+		if status != "" && !status.IsValid() {
+			errors.WriteHttpError(w, invalidQueryParam("status", "must be one of in_progress, close, cancelled"))
+			return
+		}
+
+		minDuration, ok := parseDurationParam(w, queryParams.Get("minDuration"), "minDuration")
+		if !ok {
+			return
+		}
+
+		maxDuration, ok := parseDurationParam(w, queryParams.Get("maxDuration"), "maxDuration")
+		if !ok {
+			return
+		}
+
+		if minDuration != nil && maxDuration != nil && *maxDuration < *minDuration {
+			errors.WriteHttpError(w, invalidQueryParam("maxDuration", "must not be less than minDuration"))
+			return
+		}
+
 		ctx := r.Context()
-		res, err := hm.svc.GetPvzInfo(ctx, startDate, endDate, int32(pageNumber), int32(lim))
+		res, err := hm.svc.GetPvzInfo(ctx, model.PvzInfoFilter{
+			StartDate:    startDate,
+			EndDate:      endDate,
+			Page:         int32(pageNumber),
+			Limit:        int32(lim),
+			IncludeEmpty: includeEmpty,
+			Cursor:       cursor,
+			Status:       status,
+			MinDuration:  minDuration,
+			MaxDuration:  maxDuration,
+		})
 
 		if err != nil {
-			http.Error(w, errors.ErrInternalServerError.Error(), http.StatusInternalServerError)
+			errors.WriteHttpError(w, err)
 			return
 		}
 
@@ -118,7 +176,28 @@ func (hm *HandlerManager) Pvz(w http.ResponseWriter, r *http.Request) {
 		return
 
 	default:
-		http.Error(w, errors.ErrInvalidHtppMethod.Error(), http.StatusMethodNotAllowed)
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
 		return
 	}
 }
+
+// parseDurationParam parses an optional non-negative duration query param such
+// as "90m". On failure it writes the error response and returns false.
+func parseDurationParam(w http.ResponseWriter, value, name string) (*time.Duration, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		errors.WriteHttpError(w, invalidQueryParam(name, "invalid duration format"))
+		return nil, false
+	}
+
+	if d < 0 {
+		errors.WriteHttpError(w, invalidQueryParam(name, "must not be negative"))
+		return nil, false
+	}
+
+	return &d, true
+}
@@ -0,0 +1,62 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// PvzById returns the pvz named in the path to any role and lets moderators
+// change or deactivate it.
+func (hm *HandlerManager) PvzById(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPatch {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	if r.Method == http.MethodPatch {
+		role := r.Context().Value(middleware.Role).(string)
+		if role != string(model.RoleModerator) {
+			errors.WriteHttpError(w, errors.ErrAccessDenied)
+			return
+		}
+	}
+
+	pvzId, err := uuid.Parse(mux.Vars(r)["pvzId"])
+	if err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidPvzIdFormat)
+		return
+	}
+
+	ctx := logger.With(r.Context(), "pvz_id", pvzId)
+
+	var res *model.Pvz
+	if r.Method == http.MethodGet {
+		res, err = hm.svc.GetPvz(ctx, pvzId)
+	} else {
+		var req model.UpdatePvzRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			errors.WriteHttpError(w, errors.ErrInvalidJson)
+			return
+		}
+		if req.City != nil && !req.City.IsWellFormed() {
+			errors.WriteHttpError(w, errors.ErrInvalidCity)
+			return
+		}
+		res, err = hm.svc.UpdatePvz(ctx, pvzId, req)
+	}
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,135 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PvzById(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pvzId     = uuid.New()
+		moderator = string(model.RoleModerator)
+		employee  = string(model.RoleEmployee)
+	)
+
+	serve := func(t *testing.T, s handlerManagerFixtures, method, pvzId, role string, body any) *httptest.ResponseRecorder {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		r := mux.NewRouter()
+		r.Handle("/pvz/{pvzId}", http.HandlerFunc(s.hm.PvzById))
+		req := httptest.NewRequest(method, "/pvz/"+pvzId, bytes.NewReader(data))
+		ctx := context.WithValue(req.Context(), middleware.Role, role)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		expected := &model.Pvz{Id: pvzId, City: model.CityMoscow, Active: true}
+		s.mockSvc.EXPECT().GetPvz(gomock.Any(), pvzId).Return(expected, nil)
+
+		rec := serve(t, s, http.MethodGet, pvzId.String(), employee, nil)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res model.Pvz
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, expected.Id, res.Id)
+		assert.True(t, res.Active)
+	})
+	t.Run("get not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().GetPvz(gomock.Any(), pvzId).Return(nil, customErrors.ErrPvzDoesNotExist)
+
+		rec := serve(t, s, http.MethodGet, pvzId.String(), employee, nil)
+
+		assert.Equal(t, "pvz_not_found", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("invalid pvz id", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodGet, "test", employee, nil)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("deactivate", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		inactive := false
+		s.mockSvc.EXPECT().UpdatePvz(gomock.Any(), pvzId, model.UpdatePvzRequest{Active: &inactive}).
+			Return(&model.Pvz{Id: pvzId}, nil)
+
+		rec := serve(t, s, http.MethodPatch, pvzId.String(), moderator, map[string]any{"active": false})
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res model.Pvz
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.False(t, res.Active)
+	})
+	t.Run("update malformed city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodPatch, pvzId.String(), moderator, map[string]any{"city": ""})
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("update access denied", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodPatch, pvzId.String(), employee, map[string]any{"active": false})
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodPatch, pvzId.String(), moderator, "test")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		rec := serve(t, s, http.MethodDelete, pvzId.String(), moderator, nil)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
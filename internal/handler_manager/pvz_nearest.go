@@ -0,0 +1,73 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+const defaultNearestPvzRadius = 5000
+
+// NearestPvz lists active pvz within radius metres of lat/lon, the nearest
+// first. Any role may search.
+func (hm *HandlerManager) NearestPvz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(queryParams.Get("lat"), 64)
+	if err != nil || !model.IsValidLatitude(lat) {
+		errors.WriteHttpError(w, invalidQueryParam("lat", "must be a number between -90 and 90"))
+		return
+	}
+
+	lon, err := strconv.ParseFloat(queryParams.Get("lon"), 64)
+	if err != nil || !model.IsValidLongitude(lon) {
+		errors.WriteHttpError(w, invalidQueryParam("lon", "must be a number between -180 and 180"))
+		return
+	}
+
+	radius := float64(defaultNearestPvzRadius)
+	if radiusStr := queryParams.Get("radius"); radiusStr != "" {
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil || math.IsNaN(radius) || radius <= 0 || radius > model.MaxNearestPvzRadius {
+			errors.WriteHttpError(w, invalidQueryParam("radius", "must be greater than 0 and less than or equal to 100000 metres"))
+			return
+		}
+	}
+
+	lim := 10
+	if limit := queryParams.Get("limit"); limit != "" {
+		lim, err = strconv.Atoi(limit)
+		if err != nil {
+			errors.WriteHttpError(w, invalidQueryParam("limit", "must be integer"))
+			return
+		}
+
+		if lim < 1 || lim > 30 {
+			errors.WriteHttpError(w, invalidQueryParam("limit", "must be greater than 0 and less than or equal to 30"))
+			return
+		}
+	}
+
+	res, err := hm.svc.FindNearestPvz(r.Context(), model.NearestPvzQuery{
+		Latitude:     lat,
+		Longitude:    lon,
+		RadiusMeters: radius,
+		Limit:        lim,
+	})
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
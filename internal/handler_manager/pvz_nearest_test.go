@@ -0,0 +1,108 @@
+package handler_manager
+
+import (
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NearestPvz(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(method, query string) *http.Request {
+		req := httptest.NewRequest(method, "/pvz/nearest?"+query, nil)
+		ctx := context.WithValue(req.Context(), middleware.Role, string(model.RoleEmployee))
+		return req.WithContext(ctx)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		expected := []model.PvzDistance{{Pvz: model.Pvz{Id: uuid.New(), City: model.CityMoscow}, DistanceMeters: 350.5}}
+		s.mockSvc.EXPECT().FindNearestPvz(gomock.Any(), model.NearestPvzQuery{
+			Latitude: 55.75, Longitude: 37.62, RadiusMeters: 2000, Limit: 5,
+		}).Return(expected, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.NearestPvz(rec, newRequest(http.MethodGet, "lat=55.75&lon=37.62&radius=2000&limit=5"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res []model.PvzDistance
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, expected[0].Id, res[0].Id)
+		assert.Equal(t, 350.5, res[0].DistanceMeters)
+	})
+	t.Run("default radius and limit", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().FindNearestPvz(gomock.Any(), model.NearestPvzQuery{
+			Latitude: 55.75, Longitude: 37.62, RadiusMeters: defaultNearestPvzRadius, Limit: 10,
+		}).Return([]model.PvzDistance{}, nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.NearestPvz(rec, newRequest(http.MethodGet, "lat=55.75&lon=37.62"))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("invalid params", func(t *testing.T) {
+		t.Parallel()
+
+		for query, param := range map[string]string{
+			"lon=37.62":                       "lat",
+			"lat=91&lon=37.62":                "lat",
+			"lat=55.75":                       "lon",
+			"lat=55.75&lon=test":              "lon",
+			"lat=55.75&lon=37.62&radius=0":    "radius",
+			"lat=55.75&lon=37.62&radius=1e6":  "radius",
+			"lat=55.75&lon=37.62&radius=NaN":  "radius",
+			"lat=55.75&lon=37.62&limit=31":    "limit",
+			"lat=55.75&lon=37.62&limit=a_lot": "limit",
+		} {
+			s := setUp(t)
+			rec := httptest.NewRecorder()
+
+			s.hm.NearestPvz(rec, newRequest(http.MethodGet, query))
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+			assert.Equal(t, param, decodeErrorResponse(t, rec).Details["param"], query)
+			s.tearDown()
+		}
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockSvc.EXPECT().FindNearestPvz(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		rec := httptest.NewRecorder()
+
+		s.hm.NearestPvz(rec, newRequest(http.MethodGet, "lat=55.75&lon=37.62"))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.NearestPvz(rec, newRequest(http.MethodPost, "lat=55.75&lon=37.62"))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
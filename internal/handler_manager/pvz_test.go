@@ -1,6 +1,7 @@
 package handler_manager
 
 import (
+	customErrors "avito2/internal/errors"
 	"avito2/internal/middleware"
 	"avito2/internal/model"
 	"bytes"
@@ -11,8 +12,10 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,7 +30,7 @@ func Test_PVZ(t *testing.T) {
 		moderatorRole          = string(model.RoleModerator)
 		invalidRole            = "test"
 		requestWithInvalidCity = model.CreatePvzRequest{
-			City: "test",
+			City: "",
 		}
 	)
 
@@ -82,6 +85,88 @@ func Test_PVZ(t *testing.T) {
 		s.hm.Pvz(rec, req)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 	})
+
+	t.Run("unknown city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().CreatePvz(gomock.Any(), model.CreatePvzRequest{City: "Тверь"}).Return(nil, customErrors.ErrInvalidCity)
+		body, err := json.Marshal(model.CreatePvzRequest{City: "Тверь"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "invalid_city", decodeErrorResponse(t, rec).Code)
+	})
+
+	t.Run("create with location", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		lat, lon := 55.7558, 37.6173
+		withLocation := model.CreatePvzRequest{City: model.CityMoscow, Address: "Красная площадь, 1", Latitude: &lat, Longitude: &lon, WorkingHours: "10:00-22:00"}
+		s.mockSvc.EXPECT().CreatePvz(gomock.Any(), withLocation).Return(&model.Pvz{City: model.CityMoscow, Latitude: &lat, Longitude: &lon}, nil)
+		body, err := json.Marshal(withLocation)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var res model.Pvz
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, &lat, res.Latitude)
+	})
+
+	t.Run("invalid location", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).
+			Return(nil, customErrors.WithDetails(customErrors.ErrInvalidPvz, map[string]any{"field": "working_hours"}))
+		body, err := json.Marshal(model.CreatePvzRequest{City: model.CityMoscow, WorkingHours: "всегда"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "invalid_pvz", res.Code)
+		assert.Equal(t, "working_hours", res.Details["field"])
+	})
+
+	t.Run("inactive city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().CreatePvz(gomock.Any(), model.CreatePvzRequest{City: model.CityKazan}).Return(nil, customErrors.ErrCityInactive)
+		body, err := json.Marshal(model.CreatePvzRequest{City: model.CityKazan})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/pvz", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
 	t.Run("failed to create pvz info with internal error", func(t *testing.T) {
 		t.Parallel()
 
@@ -106,7 +191,7 @@ func Test_PVZ(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any()).Return(nil, nil)
 		req := httptest.NewRequest(http.MethodGet, "/pvz", bytes.NewReader(nil))
 		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
 		req = req.WithContext(ctx)
@@ -116,6 +201,189 @@ func Test_PVZ(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 
+	t.Run("success get pvz info including empty pvz", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, filter model.PvzInfoFilter) (*model.GetPvzInfoResponse, error) {
+				assert.True(t, filter.IncludeEmpty)
+				assert.Equal(t, int32(2), filter.Page)
+				assert.Equal(t, int32(5), filter.Limit)
+				return &model.GetPvzInfoResponse{}, nil
+			})
+		params := url.Values{}
+		params.Add("includeEmpty", "true")
+		params.Add("page", "2")
+		params.Add("limit", "5")
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("success get pvz info with cursor", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		cursor := model.PvzCursor{RegistrationDate: time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC), Id: uuid.New()}
+		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, filter model.PvzInfoFilter) (*model.GetPvzInfoResponse, error) {
+				require.NotNil(t, filter.Cursor)
+				assert.Equal(t, cursor.Id, filter.Cursor.Id)
+				return &model.GetPvzInfoResponse{}, nil
+			})
+		params := url.Values{}
+		params.Add("cursor", cursor.Encode())
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		params := url.Values{}
+		params.Add("cursor", "test")
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "invalid_query_param", res.Code)
+		assert.Equal(t, "cursor", res.Details["param"])
+	})
+
+	t.Run("page and cursor together", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		params := url.Values{}
+		params.Add("page", "2")
+		params.Add("cursor", model.PvzCursor{Id: uuid.New()}.Encode())
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid includeEmpty value", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		params := url.Values{}
+		params.Add("includeEmpty", "test")
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success get pvz info with status and duration", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, filter model.PvzInfoFilter) (*model.GetPvzInfoResponse, error) {
+				assert.Equal(t, model.ReceptionStatusClose, filter.Status)
+				require.NotNil(t, filter.MinDuration)
+				require.NotNil(t, filter.MaxDuration)
+				assert.Equal(t, 10*time.Minute, *filter.MinDuration)
+				assert.Equal(t, 2*time.Hour, *filter.MaxDuration)
+				return &model.GetPvzInfoResponse{}, nil
+			})
+		params := url.Values{}
+		params.Add("status", "close")
+		params.Add("minDuration", "10m")
+		params.Add("maxDuration", "2h")
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid status value", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		params := url.Values{}
+		params.Add("status", "test")
+		req := httptest.NewRequest(http.MethodGet, "/pvz?"+params.Encode(), bytes.NewReader(nil))
+		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		s.hm.Pvz(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "status", res.Details["param"])
+	})
+
+	t.Run("invalid duration values", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tc := range []struct {
+			name   string
+			params url.Values
+			param  string
+		}{
+			{"bad format", url.Values{"minDuration": {"ten minutes"}}, "minDuration"},
+			{"negative", url.Values{"maxDuration": {"-1h"}}, "maxDuration"},
+			{"max less than min", url.Values{"minDuration": {"2h"}, "maxDuration": {"1h"}}, "maxDuration"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+
+				s := setUp(t)
+				defer s.tearDown()
+
+				req := httptest.NewRequest(http.MethodGet, "/pvz?"+tc.params.Encode(), bytes.NewReader(nil))
+				ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
+				req = req.WithContext(ctx)
+				rec := httptest.NewRecorder()
+
+				s.hm.Pvz(rec, req)
+				assert.Equal(t, http.StatusBadRequest, rec.Code)
+				res := decodeErrorResponse(t, rec)
+				assert.Equal(t, tc.param, res.Details["param"])
+			})
+		}
+	})
+
 	t.Run("invalid startDate fromat", func(t *testing.T) {
 		t.Parallel()
 
@@ -232,7 +500,7 @@ func Test_PVZ(t *testing.T) {
 		s := setUp(t)
 		defer s.tearDown()
 
-		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to get pvz info"))
+		s.mockSvc.EXPECT().GetPvzInfo(gomock.Any(), gomock.Any()).Return(nil, errors.New("failed to get pvz info"))
 		req := httptest.NewRequest(http.MethodGet, "/pvz", bytes.NewReader(nil))
 		ctx := context.WithValue(req.Context(), middleware.Role, moderatorRole)
 		req = req.WithContext(ctx)
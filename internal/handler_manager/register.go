@@ -0,0 +1,60 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+	"net/mail"
+)
+
+const minPasswordLength = 8
+
+// maxPasswordLength is the most bytes bcrypt takes into account; longer
+// passwords are rejected by bcrypt.GenerateFromPassword.
+const maxPasswordLength = 72
+
+func (hm *HandlerManager) Register(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	var req model.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidEmail)
+		return
+	}
+
+	if len(req.Password) < minPasswordLength {
+		errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidPassword, map[string]any{"min_length": minPasswordLength}))
+		return
+	}
+
+	if len(req.Password) > maxPasswordLength {
+		errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidPassword, map[string]any{"max_length": maxPasswordLength}))
+		return
+	}
+
+	if !req.Role.IsValid() {
+		errors.WriteHttpError(w, errors.ErrInvalidRole)
+		return
+	}
+
+	ctx := r.Context()
+	res, err := hm.userSvc.Register(ctx, req.Email, req.Password, req.Role)
+
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(res)
+}
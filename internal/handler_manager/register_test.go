@@ -0,0 +1,189 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Register(t *testing.T) {
+	t.Parallel()
+
+	var (
+		request = model.RegisterRequest{
+			Email:    "employee@example.com",
+			Password: "password",
+			Role:     model.RoleEmployee,
+		}
+		requestWithInvalidEmail = model.RegisterRequest{
+			Email:    "test",
+			Password: "password",
+			Role:     model.RoleEmployee,
+		}
+		requestWithShortPassword = model.RegisterRequest{
+			Email:    "employee@example.com",
+			Password: "pass",
+			Role:     model.RoleEmployee,
+		}
+		requestWithLongPassword = model.RegisterRequest{
+			Email:    "employee@example.com",
+			Password: strings.Repeat("p", maxPasswordLength+1),
+			Role:     model.RoleEmployee,
+		}
+		requestWithInvalidRole = model.RegisterRequest{
+			Email:    "employee@example.com",
+			Password: "password",
+			Role:     "test",
+		}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.User{}, nil)
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+	t.Run("invalid email", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal(requestWithInvalidEmail)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("short password", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal(requestWithShortPassword)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("long password", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal(requestWithLongPassword)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		res := decodeErrorResponse(t, rec)
+		assert.Equal(t, "invalid_password", res.Code)
+		assert.Equal(t, float64(maxPasswordLength), res.Details["max_length"])
+	})
+	t.Run("invalid role", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal(requestWithInvalidRole)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("user already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrUserAlreadyExists)
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		s.mockUserSvc.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		body, err := json.Marshal(request)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		req := httptest.NewRequest(http.MethodGet, "/register", nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		body, err := json.Marshal("bad request")
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		s.hm.Register(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
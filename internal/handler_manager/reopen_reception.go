@@ -0,0 +1,44 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func (hm *HandlerManager) ReopenReception(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	role := r.Context().Value(middleware.Role).(string)
+	if role != string(model.RoleModerator) {
+		errors.WriteHttpError(w, errors.ErrAccessDenied)
+		return
+	}
+
+	vars := mux.Vars(r)
+	receptionId, err := uuid.Parse(vars["receptionId"])
+	if err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidReceptionIdFormat)
+		return
+	}
+
+	ctx := logger.With(r.Context(), "reception_id", receptionId)
+	res, err := hm.svc.ReopenReception(ctx, receptionId, actorFromContext(ctx))
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -1,33 +1,49 @@
 package handler_manager
 
 import (
+	customErrors "avito2/internal/errors"
 	mock_service "avito2/internal/service/mocks"
 	mock_jwt "avito2/internal/utils/mocks"
+	"encoding/json"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type handlerManagerFixtures struct {
-	ctrl       *gomock.Controller
-	hm         HandlerManager
-	mockSvc    *mock_service.MockService
-	mockJWTGen *mock_jwt.MockJWTGenerator
+	ctrl        *gomock.Controller
+	hm          HandlerManager
+	mockSvc     *mock_service.MockService
+	mockUserSvc *mock_service.MockUserService
+	mockJWTGen  *mock_jwt.MockJWTGenerator
 }
 
 func setUp(t *testing.T) handlerManagerFixtures {
 	ctrl := gomock.NewController(t)
 	mockSvc := mock_service.NewMockService(ctrl)
+	mockUserSvc := mock_service.NewMockUserService(ctrl)
 	mockJWTGen := mock_jwt.NewMockJWTGenerator(ctrl)
-	hm := NewHandlerManager(mockSvc, mockJWTGen)
+	hm := NewHandlerManager(mockSvc, mockUserSvc, mockJWTGen)
 	return handlerManagerFixtures{
-		ctrl:       ctrl,
-		hm:         *hm,
-		mockSvc:    mockSvc,
-		mockJWTGen: mockJWTGen,
+		ctrl:        ctrl,
+		hm:          *hm,
+		mockSvc:     mockSvc,
+		mockUserSvc: mockUserSvc,
+		mockJWTGen:  mockJWTGen,
 	}
 }
 
 func (h *handlerManagerFixtures) tearDown() {
 	h.ctrl.Finish()
 }
+
+func decodeErrorResponse(t *testing.T, rec *httptest.ResponseRecorder) customErrors.ErrorResponse {
+	t.Helper()
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var res customErrors.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
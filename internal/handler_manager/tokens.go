@@ -0,0 +1,100 @@
+package handler_manager
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// writeTokens signs the user in: it issues an access token and starts a new
+// refresh token family.
+func (hm *HandlerManager) writeTokens(ctx context.Context, w http.ResponseWriter, userId string, role model.Role) {
+	token, err := hm.jwtGen.GenerateJWT(userId, string(role))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate jwt", "err", err)
+		errors.WriteHttpError(w, errors.ErrInternalServerError)
+		return
+	}
+
+	refreshToken, err := hm.userSvc.IssueRefreshToken(ctx, userId, role)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(model.TokenResponse{Token: token, RefreshToken: refreshToken})
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token. The old refresh token can not be used again.
+func (hm *HandlerManager) Refresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	var req model.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteHttpError(w, errors.ErrInvalidJson)
+		return
+	}
+
+	if req.RefreshToken == "" {
+		errors.WriteHttpError(w, errors.ErrInvalidRefreshToken)
+		return
+	}
+
+	ctx := r.Context()
+	session, refreshToken, err := hm.userSvc.RefreshSession(ctx, req.RefreshToken)
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	token, err := hm.jwtGen.GenerateJWT(session.UserId, string(session.Role))
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate jwt", "err", err)
+		errors.WriteHttpError(w, errors.ErrInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(model.TokenResponse{Token: token, RefreshToken: refreshToken})
+}
+
+// Logout revokes the access token of the request and, if the body carries
+// one, the refresh token with every token refreshed from it.
+func (hm *HandlerManager) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		errors.WriteHttpError(w, errors.ErrInvalidHtppMethod)
+		return
+	}
+
+	var req model.RefreshTokenRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			errors.WriteHttpError(w, errors.ErrInvalidJson)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	userId, _ := ctx.Value(middleware.UserId).(string)
+	tokenId, _ := ctx.Value(middleware.TokenId).(string)
+	expiresAt, _ := ctx.Value(middleware.TokenExpiresAt).(time.Time)
+	err := hm.userSvc.Logout(ctx, userId, req.RefreshToken, model.RevokedToken{Id: tokenId, ExpiresAt: expiresAt})
+	if err != nil {
+		errors.WriteHttpError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,210 @@
+package handler_manager
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Refresh(t *testing.T) {
+	t.Parallel()
+
+	var (
+		request = model.RefreshTokenRequest{RefreshToken: "old"}
+		session = &model.RefreshToken{
+			Id:       uuid.New(),
+			FamilyId: uuid.New(),
+			UserId:   uuid.NewString(),
+			Role:     model.RoleModerator,
+		}
+	)
+
+	newRequest := func(t *testing.T, method string, body any) *http.Request {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		return httptest.NewRequest(method, "/auth/refresh", bytes.NewReader(data))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().RefreshSession(gomock.Any(), "old").Return(session, "new", nil)
+		s.mockJWTGen.EXPECT().GenerateJWT(session.UserId, string(session.Role)).Return("token", nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.Refresh(rec, newRequest(t, http.MethodPost, request))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var res model.TokenResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, model.TokenResponse{Token: "token", RefreshToken: "new"}, res)
+	})
+	t.Run("invalid refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Return(nil, "", customErrors.ErrInvalidRefreshToken)
+		rec := httptest.NewRecorder()
+
+		s.hm.Refresh(rec, newRequest(t, http.MethodPost, request))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "invalid_refresh_token", decodeErrorResponse(t, rec).Code)
+	})
+	t.Run("empty refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Refresh(rec, newRequest(t, http.MethodPost, model.RefreshTokenRequest{}))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+	t.Run("failed to generate jwt", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().RefreshSession(gomock.Any(), gomock.Any()).Return(session, "new", nil)
+		s.mockJWTGen.EXPECT().GenerateJWT(gomock.Any(), gomock.Any()).Return("", errors.New("failed to generate jwt"))
+		rec := httptest.NewRecorder()
+
+		s.hm.Refresh(rec, newRequest(t, http.MethodPost, request))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Refresh(rec, newRequest(t, http.MethodPost, "test"))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Refresh(rec, newRequest(t, http.MethodGet, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func Test_Logout(t *testing.T) {
+	t.Parallel()
+
+	var (
+		userId    = uuid.NewString()
+		tokenId   = uuid.NewString()
+		expiresAt = time.Now().Add(15 * time.Minute).Truncate(time.Second)
+		revoked   = model.RevokedToken{Id: tokenId, ExpiresAt: expiresAt}
+	)
+
+	newRequest := func(t *testing.T, method string, body []byte) *http.Request {
+		req := httptest.NewRequest(method, "/auth/logout", bytes.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.UserId, userId)
+		ctx = context.WithValue(ctx, middleware.TokenId, tokenId)
+		ctx = context.WithValue(ctx, middleware.TokenExpiresAt, expiresAt)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("with refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().Logout(gomock.Any(), userId, "refresh", revoked).Return(nil)
+		body, err := json.Marshal(model.RefreshTokenRequest{RefreshToken: "refresh"})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+
+		s.hm.Logout(rec, newRequest(t, http.MethodPost, body))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("without body", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().Logout(gomock.Any(), userId, "", revoked).Return(nil)
+		rec := httptest.NewRecorder()
+
+		s.hm.Logout(rec, newRequest(t, http.MethodPost, nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+	t.Run("refresh token of another user", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().Logout(gomock.Any(), userId, "refresh", revoked).Return(customErrors.ErrAccessDenied)
+		body, err := json.Marshal(model.RefreshTokenRequest{RefreshToken: "refresh"})
+		require.NoError(t, err)
+		rec := httptest.NewRecorder()
+
+		s.hm.Logout(rec, newRequest(t, http.MethodPost, body))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+	t.Run("internal error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserSvc.EXPECT().Logout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		rec := httptest.NewRecorder()
+
+		s.hm.Logout(rec, newRequest(t, http.MethodPost, nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Logout(rec, newRequest(t, http.MethodPost, []byte("test")))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+	t.Run("invalid http method", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		rec := httptest.NewRecorder()
+
+		s.hm.Logout(rec, newRequest(t, http.MethodGet, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
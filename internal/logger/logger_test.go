@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FromContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default logger", func(t *testing.T) {
+		t.Parallel()
+
+		l := FromContext(context.Background())
+
+		assert.Equal(t, slog.Default(), l)
+	})
+
+	t.Run("attributes are propagated", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		ctx := WithContext(context.Background(), New(&buf))
+		ctx = With(ctx, "request_id", "42")
+		ctx = With(ctx, "pvz_id", "pvz")
+
+		FromContext(ctx).Error("failed", "err", "boom")
+
+		line := map[string]any{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "failed", line["msg"])
+		assert.Equal(t, "42", line["request_id"])
+		assert.Equal(t, "pvz", line["pvz_id"])
+		assert.Equal(t, "boom", line["err"])
+	})
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "pvz_manager"
+
+var (
+	HttpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	HttpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	PvzCreatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pvz_created_total",
+		Help:      "Total number of created pvz.",
+	}, []string{"city"})
+
+	ReceptionsCreatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "receptions_created_total",
+		Help:      "Total number of opened receptions.",
+	}, []string{"city"})
+
+	ReceptionsClosedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "receptions_closed_total",
+		Help:      "Total number of closed receptions.",
+	}, []string{"city"})
+
+	ReceptionsCancelledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "receptions_cancelled_total",
+		Help:      "Total number of cancelled receptions.",
+	}, []string{"city"})
+
+	ReceptionsReopenedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "receptions_reopened_total",
+		Help:      "Total number of reopened receptions.",
+	}, []string{"city"})
+
+	ProductsAddedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "products_added_total",
+		Help:      "Total number of products added to receptions.",
+	}, []string{"city", "type"})
+
+	ProductsDeletedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "products_deleted_total",
+		Help:      "Total number of products deleted from receptions.",
+	}, []string{"city", "type"})
+)
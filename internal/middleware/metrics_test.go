@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"avito2/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MetricsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("labels request with route template", func(t *testing.T) {
+		t.Parallel()
+
+		route := "/metrics_test/{id}/created"
+		router := mux.NewRouter()
+		router.Use(MetricsMiddleware)
+		router.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		before := testutil.ToFloat64(metrics.HttpRequestsTotal.WithLabelValues(route, http.MethodPost, "201"))
+
+		req := httptest.NewRequest(http.MethodPost, "/metrics_test/42/created", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.HttpRequestsTotal.WithLabelValues(route, http.MethodPost, "201")))
+	})
+	t.Run("defaults status to 200", func(t *testing.T) {
+		t.Parallel()
+
+		route := "/metrics_test/ok"
+		router := mux.NewRouter()
+		router.Use(MetricsMiddleware)
+		router.HandleFunc(route, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		})
+
+		before := testutil.ToFloat64(metrics.HttpRequestsTotal.WithLabelValues(route, http.MethodGet, "200"))
+
+		req := httptest.NewRequest(http.MethodGet, route, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.HttpRequestsTotal.WithLabelValues(route, http.MethodGet, "200")))
+	})
+}
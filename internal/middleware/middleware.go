@@ -1,49 +1,99 @@
 package middleware
 
 import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/utils"
 	"context"
 	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
 type key string
 
-const Role key = "role"
+const (
+	Role           key = "role"
+	UserId         key = "user_id"
+	TokenId        key = "token_id"
+	TokenExpiresAt key = "token_expires_at"
+)
+
+// TokenDenylist tells whether an access token was revoked before it expired.
+type TokenDenylist interface {
+	IsRevoked(ctx context.Context, tokenId string) (bool, error)
+}
+
+// AuthMiddleware verifies the bearer token of every request with the key its
+// kid names, accepting only the algorithms in utils.SigningMethods, rejects
+// tokens on the denylist and puts the caller's role and id and the token's jti
+// and expiry into the context.
+type AuthMiddleware struct {
+	keys     *utils.KeySet
+	denylist TokenDenylist
+}
 
-func AuthMiddleware(next http.Handler) http.Handler {
+func NewAuthMiddleware(keys *utils.KeySet, denylist TokenDenylist) *AuthMiddleware {
+	return &AuthMiddleware{
+		keys:     keys,
+		denylist: denylist,
+	}
+}
+
+func (m *AuthMiddleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		tokenString := r.Header.Get("Authorization")
 		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 		if tokenString == "" {
-			http.Error(w, "empty token", http.StatusUnauthorized)
+			errors.WriteHttpError(w, errors.ErrEmptyToken)
 			return
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
+		token, err := jwt.Parse(tokenString, m.keys.Keyfunc, jwt.WithValidMethods(utils.SigningMethods))
 
 		if err != nil || !token.Valid {
-			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			errors.WriteHttpError(w, errors.ErrInvalidToken)
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			http.Error(w, "invalid token claims", http.StatusUnauthorized)
+			errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidToken, map[string]any{"reason": "invalid token claims"}))
 			return
 		}
 
 		role, ok := claims["role"].(string)
 		if !ok {
-			http.Error(w, "role not found in token", http.StatusUnauthorized)
+			errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidToken, map[string]any{"reason": "role not found in token"}))
 			return
 		}
 
+		tokenId, ok := claims["jti"].(string)
+		if !ok {
+			errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidToken, map[string]any{"reason": "token id not found in token"}))
+			return
+		}
+
+		revoked, err := m.denylist.IsRevoked(r.Context(), tokenId)
+		if err != nil {
+			errors.WriteHttpError(w, errors.ErrInternalServerError)
+			return
+		}
+		if revoked {
+			errors.WriteHttpError(w, errors.WithDetails(errors.ErrInvalidToken, map[string]any{"reason": "token revoked"}))
+			return
+		}
+
+		userId, _ := claims["sub"].(string)
+		exp, _ := claims["exp"].(float64)
+
 		ctx := context.WithValue(r.Context(), Role, role)
+		ctx = context.WithValue(ctx, UserId, userId)
+		ctx = context.WithValue(ctx, TokenId, tokenId)
+		ctx = context.WithValue(ctx, TokenExpiresAt, time.Unix(int64(exp), 0))
+		ctx = logger.With(ctx, "role", role, "user_id", userId)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
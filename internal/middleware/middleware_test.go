@@ -3,30 +3,76 @@ package middleware
 import (
 	"avito2/internal/model"
 	"avito2/internal/utils"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// fakeDenylist revokes the token ids it holds and fails with err if set.
+type fakeDenylist struct {
+	revoked []string
+	err     error
+}
+
+func (d fakeDenylist) IsRevoked(_ context.Context, tokenId string) (bool, error) {
+	if d.err != nil {
+		return false, d.err
+	}
+	for _, id := range d.revoked {
+		if id == tokenId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func Test_Middleware(t *testing.T) {
 	t.Parallel()
 
 	var (
-		secret = "test"
+		userId = "8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a"
 		role   = string(model.RoleEmployee)
 	)
 
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	err = os.WriteFile(filepath.Join(dir, "test.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+	require.NoError(t, err)
+	keys, err := utils.LoadKeySet(dir, "")
+	require.NoError(t, err)
+
+	// sign signs claims with the test key the way an attacker might, picking
+	// the algorithm freely.
+	sign := func(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = "test"
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+		return tokenString
+	}
+
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 
-		os.Setenv("JWT_SECRET", secret)
-		jwtGen := utils.JWTGen{}
-		token, err := jwtGen.GenerateJWT(role)
+		jwtGen := utils.NewJWTGen(keys, time.Hour)
+		token, err := jwtGen.GenerateJWT(userId, role)
 		require.NoError(t, err)
 
 		called := false
@@ -34,6 +80,9 @@ func Test_Middleware(t *testing.T) {
 			called = true
 			val := r.Context().Value(Role)
 			assert.Equal(t, role, val)
+			assert.Equal(t, userId, r.Context().Value(UserId))
+			assert.NotEmpty(t, r.Context().Value(TokenId))
+			assert.WithinDuration(t, time.Now().Add(time.Hour), r.Context().Value(TokenExpiresAt).(time.Time), time.Minute)
 			w.WriteHeader(http.StatusOK)
 		})
 
@@ -41,7 +90,7 @@ func Test_Middleware(t *testing.T) {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 		rec := httptest.NewRecorder()
 
-		mw := AuthMiddleware(nextHandler)
+		mw := NewAuthMiddleware(keys, fakeDenylist{}).Handle(nextHandler)
 		mw.ServeHTTP(rec, req)
 
 		assert.Equal(t, http.StatusOK, rec.Code)
@@ -62,7 +111,7 @@ func Test_Middleware(t *testing.T) {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 		rec := httptest.NewRecorder()
 
-		mw := AuthMiddleware(nextHandler)
+		mw := NewAuthMiddleware(keys, fakeDenylist{}).Handle(nextHandler)
 		mw.ServeHTTP(rec, req)
 
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
@@ -83,10 +132,139 @@ func Test_Middleware(t *testing.T) {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 		rec := httptest.NewRecorder()
 
-		mw := AuthMiddleware(nextHandler)
+		mw := NewAuthMiddleware(keys, fakeDenylist{}).Handle(nextHandler)
+		mw.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.False(t, called)
+	})
+	t.Run("token signed with another key", func(t *testing.T) {
+		t.Parallel()
+
+		other, err := utils.NewEphemeralKeySet()
+		require.NoError(t, err)
+		jwtGen := utils.NewJWTGen(other, time.Hour)
+		token, err := jwtGen.GenerateJWT(userId, role)
+		require.NoError(t, err)
+
+		called := false
+		nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+
+		mw := NewAuthMiddleware(keys, fakeDenylist{}).Handle(nextHandler)
+		mw.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.False(t, called)
+	})
+	t.Run("revoked token", func(t *testing.T) {
+		t.Parallel()
+
+		jwtGen := utils.NewJWTGen(keys, time.Hour)
+		token, err := jwtGen.GenerateJWT(userId, role)
+		require.NoError(t, err)
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+		require.NoError(t, err)
+		tokenId := parsed.Claims.(jwt.MapClaims)["jti"].(string)
+
+		called := false
+		nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+
+		mw := NewAuthMiddleware(keys, fakeDenylist{revoked: []string{tokenId}}).Handle(nextHandler)
 		mw.ServeHTTP(rec, req)
 
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 		assert.False(t, called)
 	})
+	t.Run("token without id", func(t *testing.T) {
+		t.Parallel()
+
+		token := sign(t, jwt.SigningMethodEdDSA, private, jwt.MapClaims{
+			"sub":  userId,
+			"role": role,
+			"exp":  time.Now().Add(time.Hour).Unix(),
+		})
+
+		called := false
+		nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+
+		mw := NewAuthMiddleware(keys, fakeDenylist{}).Handle(nextHandler)
+		mw.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.False(t, called)
+	})
+	t.Run("algorithm not allowed", func(t *testing.T) {
+		t.Parallel()
+
+		claims := jwt.MapClaims{
+			"jti":  "8d5f3a56-1d6f-4c43-9f0e-6a4c1d2b3e4f",
+			"sub":  userId,
+			"role": role,
+			"exp":  time.Now().Add(time.Hour).Unix(),
+		}
+		for _, token := range []string{
+			sign(t, jwt.SigningMethodHS256, []byte(private.Public().(ed25519.PublicKey)), claims),
+			sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, claims),
+		} {
+			called := false
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			rec := httptest.NewRecorder()
+
+			mw := NewAuthMiddleware(keys, fakeDenylist{}).Handle(nextHandler)
+			mw.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.False(t, called)
+		}
+	})
+	t.Run("denylist error", func(t *testing.T) {
+		t.Parallel()
+
+		jwtGen := utils.NewJWTGen(keys, time.Hour)
+		token, err := jwtGen.GenerateJWT(userId, role)
+		require.NoError(t, err)
+
+		called := false
+		nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+
+		mw := NewAuthMiddleware(keys, fakeDenylist{err: errors.New("db error")}).Handle(nextHandler)
+		mw.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.False(t, called)
+	})
 }
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"avito2/internal/logger"
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	RequestId       key = "request_id"
+	RequestIdHeader     = "X-Request-ID"
+
+	maxRequestIdLength = 128
+)
+
+// RequestIdMiddleware is meant to be installed with mux.Router.Use. It takes
+// the request id from the X-Request-ID header or, if it is missing or not a
+// valid id, generates a new one, echoes it in the response and stores a logger
+// tagged with it in the context.
+func RequestIdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestId := r.Header.Get(RequestIdHeader)
+		if !isValidRequestId(requestId) {
+			requestId = uuid.NewString()
+		}
+		w.Header().Set(RequestIdHeader, requestId)
+
+		route := r.URL.Path
+		if cur := mux.CurrentRoute(r); cur != nil {
+			if tpl, err := cur.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), RequestId, requestId)
+		ctx = logger.With(ctx, "request_id", requestId, "method", r.Method, "route", route)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isValidRequestId accepts up to maxRequestIdLength letters, digits, dots,
+// underscores and dashes, so that a client cannot inject arbitrary text into
+// logs and response headers.
+func isValidRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return false
+	}
+	for _, c := range requestId {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
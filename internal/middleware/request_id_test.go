@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"avito2/internal/logger"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestIdMiddleware(t *testing.T) {
+	t.Parallel()
+
+	newRouter := func(buf *bytes.Buffer) http.Handler {
+		router := mux.NewRouter()
+		router.Use(RequestIdMiddleware)
+		router.HandleFunc("/pvz/{pvzId}", func(w http.ResponseWriter, r *http.Request) {
+			logger.FromContext(r.Context()).Info("handled")
+			w.WriteHeader(http.StatusOK)
+		})
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := logger.WithContext(r.Context(), logger.New(buf))
+			router.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	t.Run("honours incoming request id", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		req := httptest.NewRequest(http.MethodGet, "/pvz/1", nil)
+		req.Header.Set(RequestIdHeader, "req-1")
+		rec := httptest.NewRecorder()
+
+		newRouter(&buf).ServeHTTP(rec, req)
+
+		assert.Equal(t, "req-1", rec.Header().Get(RequestIdHeader))
+		line := map[string]any{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "req-1", line["request_id"])
+		assert.Equal(t, "/pvz/{pvzId}", line["route"])
+		assert.Equal(t, http.MethodGet, line["method"])
+	})
+
+	t.Run("generates request id", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		req := httptest.NewRequest(http.MethodGet, "/pvz/1", nil)
+		rec := httptest.NewRecorder()
+
+		newRouter(&buf).ServeHTTP(rec, req)
+
+		requestId := rec.Header().Get(RequestIdHeader)
+		assert.NotEmpty(t, requestId)
+		line := map[string]any{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, requestId, line["request_id"])
+	})
+
+	t.Run("replaces invalid request id", func(t *testing.T) {
+		t.Parallel()
+
+		for _, requestId := range []string{"req 1\nfake log line", "req-<script>", strings.Repeat("a", maxRequestIdLength+1)} {
+			var buf bytes.Buffer
+			req := httptest.NewRequest(http.MethodGet, "/pvz/1", nil)
+			req.Header.Set(RequestIdHeader, requestId)
+			rec := httptest.NewRecorder()
+
+			newRouter(&buf).ServeHTTP(rec, req)
+
+			generated := rec.Header().Get(RequestIdHeader)
+			assert.NotEqual(t, requestId, generated)
+			assert.NoError(t, uuid.Validate(generated))
+			line := map[string]any{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, generated, line["request_id"])
+		}
+	})
+}
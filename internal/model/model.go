@@ -1,7 +1,13 @@
 package model
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"time"
+	// the city registry validates IANA timezones, which must not depend on
+	// the zoneinfo files of the host
+	_ "time/tzdata"
 
 	"github.com/google/uuid"
 )
@@ -21,6 +27,9 @@ func (r Role) IsValid() bool {
 	return false
 }
 
+// City is a city where pvz may be opened. The cities are stored in the cities
+// table and managed by moderators; the constants are the cities seeded by the
+// migration.
 type City string
 
 const (
@@ -29,12 +38,75 @@ const (
 	CitySaintPetersburg City = "Санкт-Петербург"
 )
 
-func (c City) IsValid() bool {
-	switch c {
-	case CityMoscow, CityKazan, CitySaintPetersburg:
-		return true
+// IsWellFormed reports whether c can be used as the name of a new city.
+// Whether the city exists is checked against the cities table.
+func (c City) IsWellFormed() bool {
+	return c != "" && len(c) <= 256 && strings.TrimSpace(string(c)) == string(c)
+}
+
+// CityInfo is an entry of the city registry. New pvz may be opened only in
+// active cities; deactivating a city keeps its existing pvz.
+type CityInfo struct {
+	Name      City      `json:"name" db:"name"`
+	Region    string    `json:"region" db:"region"`
+	Timezone  string    `json:"timezone" db:"timezone"`
+	Active    bool      `json:"active" db:"active"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// InvalidField returns the name of the first field that is missing or
+// malformed, or an empty string if the city is valid. The timezone must be an
+// IANA name such as Europe/Moscow.
+func (c CityInfo) InvalidField() string {
+	switch {
+	case !c.Name.IsWellFormed():
+		return "name"
+	case !isValidRegion(c.Region):
+		return "region"
+	case !isValidTimezone(c.Timezone):
+		return "timezone"
 	}
-	return false
+	return ""
+}
+
+func isValidRegion(region string) bool {
+	return region != "" && len(region) <= 256
+}
+
+func isValidTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// CreateCityRequest adds a city to the registry. A city is active unless
+// active is false.
+type CreateCityRequest struct {
+	Name     City   `json:"name"`
+	Region   string `json:"region"`
+	Timezone string `json:"timezone"`
+	Active   *bool  `json:"active,omitempty"`
+}
+
+// UpdateCityRequest changes only the fields that are present.
+type UpdateCityRequest struct {
+	Region   *string `json:"region,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+	Active   *bool   `json:"active,omitempty"`
+}
+
+// InvalidField returns the name of the first present field that is malformed,
+// or an empty string if the request is valid.
+func (r UpdateCityRequest) InvalidField() string {
+	switch {
+	case r.Region != nil && !isValidRegion(*r.Region):
+		return "region"
+	case r.Timezone != nil && !isValidTimezone(*r.Timezone):
+		return "timezone"
+	}
+	return ""
 }
 
 type ReceptionStatus string
@@ -42,8 +114,41 @@ type ReceptionStatus string
 const (
 	ReceptionStatusInProgress ReceptionStatus = "in_progress"
 	ReceptionStatusClose      ReceptionStatus = "close"
+	ReceptionStatusCancelled  ReceptionStatus = "cancelled"
 )
 
+// ReceptionReopenWindow is how long after closing a reception a moderator may
+// still reopen it.
+const ReceptionReopenWindow = 24 * time.Hour
+
+// receptionTransitions is the reception state machine: a reception in progress
+// is either closed or cancelled, and a closed one can be reopened. Cancelled
+// receptions are final.
+var receptionTransitions = map[ReceptionStatus][]ReceptionStatus{
+	ReceptionStatusInProgress: {ReceptionStatusClose, ReceptionStatusCancelled},
+	ReceptionStatusClose:      {ReceptionStatusInProgress},
+}
+
+func (s ReceptionStatus) IsValid() bool {
+	switch s {
+	case ReceptionStatusInProgress, ReceptionStatusClose, ReceptionStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (s ReceptionStatus) CanTransitionTo(to ReceptionStatus) bool {
+	for _, allowed := range receptionTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductType is a product category. The allowed values are stored in the
+// product_types table and managed by moderators; the constants are the values
+// seeded by the migration.
 type ProductType string
 
 const (
@@ -52,42 +157,246 @@ const (
 	ProductTypeShoes       ProductType = "обувь"
 )
 
-func (p ProductType) IsValid() bool {
-	switch p {
-	case ProductTypeClothes, ProductTypeElectronics, ProductTypeShoes:
-		return true
-	}
-	return false
+// IsWellFormed reports whether p can be used as the name of a new product type.
+// Whether the type exists is checked against the product_types table.
+func (p ProductType) IsWellFormed() bool {
+	return p != "" && len(p) <= 256 && strings.TrimSpace(string(p)) == string(p)
+}
+
+type ProductTypeInfo struct {
+	Name      ProductType `json:"name" db:"name"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+}
+
+type ProductTypeRequest struct {
+	Name ProductType `json:"name"`
 }
 
 type DummyLoginRequest struct {
 	Role Role `json:"role"`
 }
 
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// TokenResponse is returned by every endpoint that signs the caller in. Token
+// is a short-lived access token and RefreshToken renews it through
+// POST /auth/refresh.
+type TokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshToken is the server-side record of an issued refresh token; only the
+// SHA-256 hash of the token itself is stored. Every refresh replaces the token
+// with a new one of the same family, and presenting a replaced token revokes
+// the whole family.
+type RefreshToken struct {
+	Id        uuid.UUID  `db:"id"`
+	FamilyId  uuid.UUID  `db:"family_id"`
+	UserId    string     `db:"user_id"`
+	Role      Role       `db:"role"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+// RevokedToken is an access token revoked before it expired, identified by its
+// jti claim.
+type RevokedToken struct {
+	Id        string    `db:"jti"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+type User struct {
+	Id           uuid.UUID `json:"id" db:"id"`
+	Email        string    `json:"email" db:"email"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	Role         Role      `json:"role" db:"role"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreatePvzRequest opens a pvz. Address, coordinates and working hours are
+// optional, but latitude and longitude go together.
 type CreatePvzRequest struct {
-	City City `json:"city"`
+	City         City     `json:"city"`
+	Address      string   `json:"address,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	WorkingHours string   `json:"working_hours,omitempty"`
+}
+
+// InvalidField returns the name of the first field that is malformed, or an
+// empty string if the request is valid. The city is checked against the
+// registry by the service.
+func (r CreatePvzRequest) InvalidField() string {
+	switch {
+	case len(r.Address) > maxAddressLength:
+		return "address"
+	case (r.Latitude == nil) != (r.Longitude == nil) || r.Latitude != nil && !IsValidLatitude(*r.Latitude):
+		return "latitude"
+	case r.Longitude != nil && !IsValidLongitude(*r.Longitude):
+		return "longitude"
+	case r.WorkingHours != "" && !IsValidWorkingHours(r.WorkingHours):
+		return "working_hours"
+	}
+	return ""
+}
+
+const maxAddressLength = 512
+
+func IsValidLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
 }
 
+func IsValidLongitude(lon float64) bool {
+	return lon >= -180 && lon <= 180
+}
+
+// IsValidWorkingHours accepts opening hours such as 09:00-21:00. Hours that
+// end before they start run past midnight and 00:00-24:00 means round the
+// clock.
+func IsValidWorkingHours(hours string) bool {
+	opens, closes, ok := strings.Cut(hours, "-")
+	if !ok || opens == closes {
+		return false
+	}
+	if _, err := time.Parse("15:04", opens); err != nil || len(opens) != 5 {
+		return false
+	}
+	if closes == "24:00" {
+		return true
+	}
+	_, err := time.Parse("15:04", closes)
+	return err == nil && len(closes) == 5
+}
+
+// Pvz is a pickup point. A deactivated pvz accepts no new receptions or
+// products, but its history stays readable.
 type Pvz struct {
-	Id               uuid.UUID `json:"id" db:"id"`
-	RegistrationDate time.Time `json:"registration_date" db:"registration_date"`
-	City             City      `json:"city" db:"city"`
+	Id               uuid.UUID  `json:"id" db:"id"`
+	RegistrationDate time.Time  `json:"registration_date" db:"registration_date"`
+	City             City       `json:"city" db:"city"`
+	Address          string     `json:"address,omitempty" db:"address"`
+	Latitude         *float64   `json:"latitude,omitempty" db:"latitude"`
+	Longitude        *float64   `json:"longitude,omitempty" db:"longitude"`
+	WorkingHours     string     `json:"working_hours,omitempty" db:"working_hours"`
+	Active           bool       `json:"active" db:"active"`
+	DeactivatedAt    *time.Time `json:"deactivated_at,omitempty" db:"deactivated_at"`
+}
+
+// UpdatePvzRequest changes only the fields that are present. Setting active to
+// false deactivates the pvz and setting it to true reactivates it. Latitude and
+// longitude are changed together.
+type UpdatePvzRequest struct {
+	City         *City    `json:"city,omitempty"`
+	Address      *string  `json:"address,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	WorkingHours *string  `json:"working_hours,omitempty"`
+	Active       *bool    `json:"active,omitempty"`
+}
+
+// InvalidField returns the name of the first present field that is malformed,
+// or an empty string if the request is valid. The city is checked against the
+// registry by the service.
+func (r UpdatePvzRequest) InvalidField() string {
+	switch {
+	case r.Address != nil && len(*r.Address) > maxAddressLength:
+		return "address"
+	case (r.Latitude == nil) != (r.Longitude == nil) || r.Latitude != nil && !IsValidLatitude(*r.Latitude):
+		return "latitude"
+	case r.Longitude != nil && !IsValidLongitude(*r.Longitude):
+		return "longitude"
+	case r.WorkingHours != nil && *r.WorkingHours != "" && !IsValidWorkingHours(*r.WorkingHours):
+		return "working_hours"
+	}
+	return ""
+}
+
+// MaxNearestPvzRadius bounds the search radius of the nearest pvz lookup, in
+// metres.
+const MaxNearestPvzRadius = 100_000
+
+// NearestPvzQuery looks for active pvz within RadiusMeters of a point.
+type NearestPvzQuery struct {
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+	Limit        int
+}
+
+// PvzDistance is a pvz found by the nearest pvz lookup together with its
+// great-circle distance from the point, in metres.
+type PvzDistance struct {
+	Pvz
+	DistanceMeters float64 `json:"distance_meters"`
 }
 
+// Reception is a batch of products accepted by a pvz. ClosedAt and ClosedBy are
+// set only while the reception is closed.
 type Reception struct {
 	Id       uuid.UUID       `json:"id" db:"id"`
 	DateTime time.Time       `json:"date_time" db:"date_time"`
 	PvzId    uuid.UUID       `json:"pvz_id" db:"pvz_id"`
 	Status   ReceptionStatus `json:"status" db:"status"`
+	ClosedAt *time.Time      `json:"closed_at,omitempty" db:"closed_at"`
+	ClosedBy string          `json:"closed_by,omitempty" db:"closed_by"`
+}
+
+// Actor is the authenticated user performing an action.
+type Actor struct {
+	Id   string
+	Role Role
+}
+
+// ReceptionTransition records a status change of a reception. FromStatus is
+// empty for the transition that created the reception.
+type ReceptionTransition struct {
+	Id          uuid.UUID       `json:"id" db:"id"`
+	ReceptionId uuid.UUID       `json:"reception_id" db:"reception_id"`
+	FromStatus  ReceptionStatus `json:"from_status,omitempty" db:"from_status"`
+	ToStatus    ReceptionStatus `json:"to_status" db:"to_status"`
+	ActorId     string          `json:"actor_id" db:"actor_id"`
+	ActorRole   Role            `json:"actor_role" db:"actor_role"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ProductDeletion is the audit record of a product removed from a reception.
+// The product fields are copied because the product row itself is gone.
+type ProductDeletion struct {
+	Id              uuid.UUID   `json:"id" db:"id"`
+	ProductId       uuid.UUID   `json:"product_id" db:"product_id"`
+	ProductType     ProductType `json:"product_type" db:"product_type"`
+	ProductDateTime time.Time   `json:"product_date_time" db:"product_date_time"`
+	ReceptionId     uuid.UUID   `json:"reception_id" db:"reception_id"`
+	ActorId         string      `json:"actor_id" db:"actor_id"`
+	ActorRole       Role        `json:"actor_role" db:"actor_role"`
+	DeletedAt       time.Time   `json:"deleted_at" db:"deleted_at"`
 }
 
 type CreateReceptionRequest struct {
 	PvzId string `json:"pvz_id"`
 }
 
+// AddProductRequest adds one product. With a barcode the type comes from the
+// catalog and may be omitted; if both are given they must match.
 type AddProductRequest struct {
-	Type  ProductType `json:"type"`
-	PvzId string      `json:"pvz_id"`
+	Type    ProductType `json:"type"`
+	PvzId   string      `json:"pvz_id"`
+	Barcode string      `json:"barcode,omitempty"`
 }
 
 type Product struct {
@@ -95,6 +404,94 @@ type Product struct {
 	DateTime    time.Time   `json:"date_time" db:"date_time"`
 	Type        ProductType `json:"type" db:"type"`
 	ReceptionId string      `json:"reception_id" db:"reception_id"`
+	Barcode     string      `json:"barcode,omitempty" db:"barcode"`
+}
+
+// CatalogItem describes a product that can be accepted by barcode. Weight is
+// in grams and dimensions are in millimetres.
+type CatalogItem struct {
+	Barcode     string      `json:"barcode" db:"barcode"`
+	Sku         string      `json:"sku" db:"sku"`
+	Name        string      `json:"name" db:"name"`
+	Type        ProductType `json:"type" db:"type"`
+	WeightGrams int32       `json:"weight_grams" db:"weight_grams"`
+	LengthMm    int32       `json:"length_mm" db:"length_mm"`
+	WidthMm     int32       `json:"width_mm" db:"width_mm"`
+	HeightMm    int32       `json:"height_mm" db:"height_mm"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+}
+
+// InvalidField returns the name of the first field that is missing or out of
+// range, or an empty string if the item is valid. The type is checked against
+// the product_types table by the service.
+func (c CatalogItem) InvalidField() string {
+	switch {
+	case !IsValidBarcode(c.Barcode):
+		return "barcode"
+	case c.Sku == "" || len(c.Sku) > 64:
+		return "sku"
+	case c.Name == "" || len(c.Name) > 256:
+		return "name"
+	case c.WeightGrams <= 0:
+		return "weight_grams"
+	case c.LengthMm <= 0:
+		return "length_mm"
+	case c.WidthMm <= 0:
+		return "width_mm"
+	case c.HeightMm <= 0:
+		return "height_mm"
+	}
+	return ""
+}
+
+// ProductLocation tells where a product with a barcode was accepted.
+type ProductLocation struct {
+	ProductId       uuid.UUID       `json:"product_id" db:"product_id"`
+	Barcode         string          `json:"barcode" db:"barcode"`
+	Type            ProductType     `json:"type" db:"type"`
+	AcceptedAt      time.Time       `json:"accepted_at" db:"accepted_at"`
+	ReceptionId     uuid.UUID       `json:"reception_id" db:"reception_id"`
+	ReceptionStatus ReceptionStatus `json:"reception_status" db:"reception_status"`
+	PvzId           uuid.UUID       `json:"pvz_id" db:"pvz_id"`
+	City            City            `json:"city" db:"city"`
+}
+
+// MaxProductBatchSize limits the number of items in POST /products/batch.
+const MaxProductBatchSize = 100
+
+type AddProductsBatchRequest struct {
+	PvzId    string             `json:"pvz_id"`
+	Products []ProductBatchItem `json:"products"`
+}
+
+type ProductBatchItem struct {
+	Type    ProductType `json:"type"`
+	Barcode string      `json:"barcode,omitempty"`
+}
+
+type AddProductsBatchResponse struct {
+	Products []Product `json:"products"`
+}
+
+// BatchItemError describes why the item at Index of a batch was rejected.
+type BatchItemError struct {
+	Index   int    `json:"index"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// IsValidBarcode reports whether code looks like an EAN-8, UPC-A, EAN-13 or
+// GTIN-14 barcode, that is 8 to 14 digits.
+func IsValidBarcode(code string) bool {
+	if len(code) < 8 || len(code) > 14 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 type ReceptionInfo struct {
@@ -107,6 +504,47 @@ type PvzInfo struct {
 	Receptions []ReceptionInfo `json:"receptions"`
 }
 
+// PvzCursor is the keyset of the last pvz on a page. Pages are ordered by
+// (registration_date, id) descending, so the next page starts strictly after it.
+type PvzCursor struct {
+	RegistrationDate time.Time `json:"t"`
+	Id               uuid.UUID `json:"id"`
+}
+
+func (c PvzCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodePvzCursor(s string) (*PvzCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var c PvzCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// PvzInfoFilter selects the receptions returned by GET /pvz. Status and the
+// duration bounds narrow down the receptions in the period; a duration bound
+// matches only closed receptions, as the others have no duration yet.
+type PvzInfoFilter struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	Page         int32
+	Limit        int32
+	IncludeEmpty bool
+	Cursor       *PvzCursor
+	Status       ReceptionStatus
+	MinDuration  *time.Duration
+	MaxDuration  *time.Duration
+}
+
 type GetPvzInfoResponse struct {
-	PvzList []PvzInfo `json:"pvz_list"`
+	PvzList    []PvzInfo `json:"pvz_list"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
@@ -1,44 +1,119 @@
 package model
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func Test_IsValidCity(t *testing.T) {
+func Test_CityIsWellFormed(t *testing.T) {
 	t.Parallel()
 
-	t.Run("valid", func(t *testing.T) {
-		t.Parallel()
+	for name, ok := range map[City]bool{
+		CityMoscow:                     true,
+		"Новосибирск":                  true,
+		"":                             false,
+		"Новосибирск ":                 false,
+		City(strings.Repeat("a", 257)): false,
+	} {
+		assert.Equal(t, ok, name.IsWellFormed(), name)
+	}
+}
 
-		city := CityMoscow
+func Test_CityInfoInvalidField(t *testing.T) {
+	t.Parallel()
 
-		ok := city.IsValid()
+	valid := CityInfo{Name: "Новосибирск", Region: "Новосибирская область", Timezone: "Asia/Novosibirsk"}
+	assert.Empty(t, valid.InvalidField())
+
+	for field, modify := range map[string]func(c *CityInfo){
+		"name":     func(c *CityInfo) { c.Name = "" },
+		"region":   func(c *CityInfo) { c.Region = "" },
+		"timezone": func(c *CityInfo) { c.Timezone = "Asia/Atlantis" },
+	} {
+		c := valid
+		modify(&c)
+		assert.Equal(t, field, c.InvalidField())
+	}
+
+	c := valid
+	c.Timezone = ""
+	assert.Equal(t, "timezone", c.InvalidField())
+	c.Timezone = "Local"
+	assert.Equal(t, "timezone", c.InvalidField())
+}
 
-		assert.True(t, ok)
-	})
+func Test_UpdateCityRequestInvalidField(t *testing.T) {
+	t.Parallel()
 
-	t.Run("invalid", func(t *testing.T) {
-		t.Parallel()
+	empty, region, timezone := "", "Новосибирская область", "Asia/Atlantis"
+	assert.Empty(t, UpdateCityRequest{}.InvalidField())
+	assert.Empty(t, UpdateCityRequest{Region: &region}.InvalidField())
+	assert.Equal(t, "region", UpdateCityRequest{Region: &empty}.InvalidField())
+	assert.Equal(t, "timezone", UpdateCityRequest{Timezone: &timezone}.InvalidField())
+}
 
-		var city City = "test"
+func Test_IsValidWorkingHours(t *testing.T) {
+	t.Parallel()
 
-		ok := city.IsValid()
+	for hours, ok := range map[string]bool{
+		"09:00-21:00": true,
+		"22:00-06:00": true,
+		"00:00-24:00": true,
+		"9:00-21:00":  false,
+		"09:00-09:00": false,
+		"09:00":       false,
+		"24:00-09:00": false,
+		"09:00-25:00": false,
+	} {
+		assert.Equal(t, ok, IsValidWorkingHours(hours), hours)
+	}
+}
 
-		assert.False(t, ok)
-	})
+func Test_CreatePvzRequestInvalidField(t *testing.T) {
+	t.Parallel()
+
+	lat, lon, outOfRange := 55.75, 37.62, 181.0
+	assert.Empty(t, CreatePvzRequest{City: CityMoscow}.InvalidField())
+	assert.Empty(t, CreatePvzRequest{City: CityMoscow, Address: "ул. Тверская, 1", Latitude: &lat, Longitude: &lon, WorkingHours: "09:00-21:00"}.InvalidField())
+	assert.Equal(t, "latitude", CreatePvzRequest{City: CityMoscow, Latitude: &lat}.InvalidField())
+	assert.Equal(t, "latitude", CreatePvzRequest{City: CityMoscow, Latitude: &outOfRange, Longitude: &lon}.InvalidField())
+	assert.Equal(t, "longitude", CreatePvzRequest{City: CityMoscow, Latitude: &lat, Longitude: &outOfRange}.InvalidField())
+	assert.Equal(t, "working_hours", CreatePvzRequest{City: CityMoscow, WorkingHours: "всегда"}.InvalidField())
+	assert.Equal(t, "address", CreatePvzRequest{City: CityMoscow, Address: strings.Repeat("a", 513)}.InvalidField())
+
+	empty := ""
+	assert.Empty(t, UpdatePvzRequest{WorkingHours: &empty}.InvalidField())
+	assert.Equal(t, "latitude", UpdatePvzRequest{Longitude: &lon}.InvalidField())
 }
 
-func Test_IsValidProductType(t *testing.T) {
+func Test_ProductTypeIsWellFormed(t *testing.T) {
+	t.Parallel()
+
+	for name, ok := range map[ProductType]bool{
+		ProductTypeClothes:                    true,
+		"книги":                               true,
+		"":                                    false,
+		" книги":                              false,
+		ProductType(strings.Repeat("a", 257)): false,
+	} {
+		assert.Equal(t, ok, name.IsWellFormed(), name)
+	}
+}
+
+func Test_IsValidRole(t *testing.T) {
 	t.Parallel()
 
 	t.Run("valid", func(t *testing.T) {
 		t.Parallel()
 
-		productType := ProductTypeClothes
+		role := RoleEmployee
 
-		ok := productType.IsValid()
+		ok := role.IsValid()
 
 		assert.True(t, ok)
 	})
@@ -46,34 +121,79 @@ func Test_IsValidProductType(t *testing.T) {
 	t.Run("invalid", func(t *testing.T) {
 		t.Parallel()
 
-		var productType ProductType = "test"
+		var role Role = "test"
 
-		ok := productType.IsValid()
+		ok := role.IsValid()
 
 		assert.False(t, ok)
 	})
 }
 
-func Test_IsValidRole(t *testing.T) {
+func Test_PvzCursor(t *testing.T) {
 	t.Parallel()
 
-	t.Run("valid", func(t *testing.T) {
+	t.Run("encode and decode", func(t *testing.T) {
 		t.Parallel()
 
-		role := RoleEmployee
+		cursor := PvzCursor{
+			RegistrationDate: time.Date(2025, 4, 13, 16, 21, 5, 123456000, time.UTC),
+			Id:               uuid.New(),
+		}
 
-		ok := role.IsValid()
+		decoded, err := DecodePvzCursor(cursor.Encode())
 
-		assert.True(t, ok)
+		require.NoError(t, err)
+		assert.True(t, cursor.RegistrationDate.Equal(decoded.RegistrationDate))
+		assert.Equal(t, cursor.Id, decoded.Id)
 	})
 
 	t.Run("invalid", func(t *testing.T) {
 		t.Parallel()
 
-		var role Role = "test"
+		_, err := DecodePvzCursor("test")
 
-		ok := role.IsValid()
-
-		assert.False(t, ok)
+		assert.Error(t, err)
 	})
 }
+
+func Test_ReceptionStatusCanTransitionTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		from     ReceptionStatus
+		to       ReceptionStatus
+		expected bool
+	}{
+		{ReceptionStatusInProgress, ReceptionStatusClose, true},
+		{ReceptionStatusInProgress, ReceptionStatusCancelled, true},
+		{ReceptionStatusClose, ReceptionStatusInProgress, true},
+		{ReceptionStatusInProgress, ReceptionStatusInProgress, false},
+		{ReceptionStatusClose, ReceptionStatusCancelled, false},
+		{ReceptionStatusCancelled, ReceptionStatusInProgress, false},
+		{ReceptionStatusCancelled, ReceptionStatusClose, false},
+		{"test", ReceptionStatusClose, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func Test_IsValidBarcode(t *testing.T) {
+	t.Parallel()
+
+	for code, valid := range map[string]bool{
+		"12345670":        true,
+		"4601234567893":   true,
+		"14601234567890":  true,
+		"1234567":         false,
+		"123456789012345": false,
+		"460123456789a":   false,
+		"":                false,
+	} {
+		assert.Equal(t, valid, IsValidBarcode(code), code)
+	}
+}
@@ -0,0 +1,2 @@
+//go:generate protoc -I ../../api/proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pvz_v1/pvz.proto
+package pb
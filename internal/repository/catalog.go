@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+)
+
+const catalogItemColumns = "barcode, sku, name, type, weight_grams, length_mm, width_mm, height_mm, created_at"
+
+func scanCatalogItem(row pgx.Row) (*model.CatalogItem, error) {
+	var item model.CatalogItem
+	if err := row.Scan(&item.Barcode, &item.Sku, &item.Name, &item.Type, &item.WeightGrams, &item.LengthMm, &item.WidthMm, &item.HeightMm, &item.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *Repo) CreateCatalogItem(ctx context.Context, item model.CatalogItem) (*model.CatalogItem, error) {
+	res, err := scanCatalogItem(r.db.ExecQueryRow(ctx, `INSERT INTO catalog_items (`+catalogItemColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING `+catalogItemColumns,
+		item.Barcode, item.Sku, item.Name, item.Type, item.WeightGrams, item.LengthMm, item.WidthMm, item.HeightMm, time.Now()))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.ErrCatalogItemAlreadyExists
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetCatalogItem finds the catalog item by its barcode or SKU. A code can be
+// the barcode of one item and the SKU of another; the barcode match wins.
+func (r *Repo) GetCatalogItem(ctx context.Context, tx pgx.Tx, code string) (*model.CatalogItem, error) {
+	return getCatalogItem(ctx, tx, `SELECT `+catalogItemColumns+` FROM catalog_items
+		WHERE barcode = $1 OR sku = $1
+		ORDER BY (barcode = $1) DESC
+		LIMIT 1`, code)
+}
+
+func (r *Repo) GetCatalogItemByBarcode(ctx context.Context, tx pgx.Tx, barcode string) (*model.CatalogItem, error) {
+	return getCatalogItem(ctx, tx, "SELECT "+catalogItemColumns+" FROM catalog_items WHERE barcode = $1", barcode)
+}
+
+// GetCatalogItemsByBarcodes returns the catalog items with any of the
+// barcodes in one query. Unknown barcodes are simply missing from the result.
+func (r *Repo) GetCatalogItemsByBarcodes(ctx context.Context, tx pgx.Tx, barcodes []string) ([]model.CatalogItem, error) {
+	rows, err := tx.Query(ctx, "SELECT "+catalogItemColumns+" FROM catalog_items WHERE barcode = ANY($1::varchar[])", barcodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []model.CatalogItem{}
+	for rows.Next() {
+		item, err := scanCatalogItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func getCatalogItem(ctx context.Context, tx pgx.Tx, query, code string) (*model.CatalogItem, error) {
+	item, err := scanCatalogItem(tx.QueryRow(ctx, query, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrCatalogItemDoesNotExist
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// FindProductsByBarcode returns every product with the barcode together with
+// its reception and pvz, the most recently accepted first.
+func (r *Repo) FindProductsByBarcode(ctx context.Context, barcode string) ([]model.ProductLocation, error) {
+	rows, err := r.db.ExecQuery(ctx, `SELECT p.id, p.barcode, p.type, p.date_time, r.id, r.status, pvz.id, pvz.city
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		JOIN pvz ON pvz.id = r.pvz_id
+		WHERE p.barcode = $1
+		ORDER BY p.date_time DESC, p.id`, barcode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []model.ProductLocation{}
+	for rows.Next() {
+		var loc model.ProductLocation
+		if err := rows.Scan(&loc.ProductId, &loc.Barcode, &loc.Type, &loc.AcceptedAt, &loc.ReceptionId, &loc.ReceptionStatus, &loc.PvzId, &loc.City); err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+)
+
+const cityColumns = "name, region, timezone, active, created_at"
+
+func scanCity(row pgx.Row) (*model.CityInfo, error) {
+	var city model.CityInfo
+	if err := row.Scan(&city.Name, &city.Region, &city.Timezone, &city.Active, &city.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &city, nil
+}
+
+func (r *Repo) ListCities(ctx context.Context) ([]model.CityInfo, error) {
+	rows, err := r.db.ExecQuery(ctx, "SELECT "+cityColumns+" FROM cities ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cities := []model.CityInfo{}
+	for rows.Next() {
+		city, err := scanCity(rows)
+		if err != nil {
+			return nil, err
+		}
+		cities = append(cities, *city)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cities, nil
+}
+
+func (r *Repo) GetCity(ctx context.Context, name model.City) (*model.CityInfo, error) {
+	city, err := scanCity(r.db.ExecQueryRow(ctx, "SELECT "+cityColumns+" FROM cities WHERE name = $1", name))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrCityDoesNotExist
+		}
+		return nil, err
+	}
+
+	return city, nil
+}
+
+func (r *Repo) CreateCity(ctx context.Context, city model.CityInfo) (*model.CityInfo, error) {
+	res, err := scanCity(r.db.ExecQueryRow(ctx, `INSERT INTO cities (`+cityColumns+`)
+		VALUES ($1, $2, $3, $4, $5) RETURNING `+cityColumns,
+		city.Name, city.Region, city.Timezone, city.Active, time.Now()))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.ErrCityAlreadyExists
+		}
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateCity changes the fields of the request that are present and keeps the
+// others.
+func (r *Repo) UpdateCity(ctx context.Context, name model.City, req model.UpdateCityRequest) (*model.CityInfo, error) {
+	city, err := scanCity(r.db.ExecQueryRow(ctx, `UPDATE cities
+		SET region = coalesce($2, region), timezone = coalesce($3, timezone), active = coalesce($4, active)
+		WHERE name = $1 RETURNING `+cityColumns,
+		name, req.Region, req.Timezone, req.Active))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrCityDoesNotExist
+		}
+		return nil, err
+	}
+
+	return city, nil
+}
+
+// DeleteCity removes a city that has no pvz. Cities with pvz can only be
+// deactivated.
+func (r *Repo) DeleteCity(ctx context.Context, name model.City) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM cities WHERE name = $1", name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.ErrCityInUse
+		}
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.ErrCityDoesNotExist
+	}
+	return nil
+}
@@ -39,60 +39,107 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 }
 
 // AddProduct mocks base method.
-func (m *MockRepository) AddProduct(ctx context.Context, tx v4.Tx, receptionId uuid.UUID, productType model.ProductType) (*model.Product, error) {
+func (m *MockRepository) AddProduct(ctx context.Context, tx v4.Tx, receptionId uuid.UUID, productType model.ProductType, barcode string) (*model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProduct", ctx, tx, receptionId, productType)
+	ret := m.ctrl.Call(m, "AddProduct", ctx, tx, receptionId, productType, barcode)
 	ret0, _ := ret[0].(*model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddProduct indicates an expected call of AddProduct.
-func (mr *MockRepositoryMockRecorder) AddProduct(ctx, tx, receptionId, productType interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) AddProduct(ctx, tx, receptionId, productType, barcode interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockRepository)(nil).AddProduct), ctx, tx, receptionId, productType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockRepository)(nil).AddProduct), ctx, tx, receptionId, productType, barcode)
 }
 
-// BeginTransaction mocks base method.
-func (m *MockRepository) BeginTransaction(ctx context.Context, options *v4.TxOptions) (v4.Tx, error) {
+// AddProducts mocks base method.
+func (m *MockRepository) AddProducts(ctx context.Context, tx v4.Tx, receptionId uuid.UUID, items []model.ProductBatchItem) ([]model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BeginTransaction", ctx, options)
-	ret0, _ := ret[0].(v4.Tx)
+	ret := m.ctrl.Call(m, "AddProducts", ctx, tx, receptionId, items)
+	ret0, _ := ret[0].([]model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// BeginTransaction indicates an expected call of BeginTransaction.
-func (mr *MockRepositoryMockRecorder) BeginTransaction(ctx, options interface{}) *gomock.Call {
+// AddProducts indicates an expected call of AddProducts.
+func (mr *MockRepositoryMockRecorder) AddProducts(ctx, tx, receptionId, items interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockRepository)(nil).BeginTransaction), ctx, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProducts", reflect.TypeOf((*MockRepository)(nil).AddProducts), ctx, tx, receptionId, items)
 }
 
-// CommitTx mocks base method.
-func (m *MockRepository) CommitTx(ctx context.Context, tx v4.Tx) {
+// CreateCatalogItem mocks base method.
+func (m *MockRepository) CreateCatalogItem(ctx context.Context, item model.CatalogItem) (*model.CatalogItem, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "CommitTx", ctx, tx)
+	ret := m.ctrl.Call(m, "CreateCatalogItem", ctx, item)
+	ret0, _ := ret[0].(*model.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CommitTx indicates an expected call of CommitTx.
-func (mr *MockRepositoryMockRecorder) CommitTx(ctx, tx interface{}) *gomock.Call {
+// CreateCatalogItem indicates an expected call of CreateCatalogItem.
+func (mr *MockRepositoryMockRecorder) CreateCatalogItem(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTx", reflect.TypeOf((*MockRepository)(nil).CommitTx), ctx, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogItem", reflect.TypeOf((*MockRepository)(nil).CreateCatalogItem), ctx, item)
+}
+
+// CreateCity mocks base method.
+func (m *MockRepository) CreateCity(ctx context.Context, city model.CityInfo) (*model.CityInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCity", ctx, city)
+	ret0, _ := ret[0].(*model.CityInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCity indicates an expected call of CreateCity.
+func (mr *MockRepositoryMockRecorder) CreateCity(ctx, city interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCity", reflect.TypeOf((*MockRepository)(nil).CreateCity), ctx, city)
+}
+
+// CreateProductDeletion mocks base method.
+func (m *MockRepository) CreateProductDeletion(ctx context.Context, tx v4.Tx, deletion model.ProductDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductDeletion", ctx, tx, deletion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductDeletion indicates an expected call of CreateProductDeletion.
+func (mr *MockRepositoryMockRecorder) CreateProductDeletion(ctx, tx, deletion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductDeletion", reflect.TypeOf((*MockRepository)(nil).CreateProductDeletion), ctx, tx, deletion)
+}
+
+// CreateProductType mocks base method.
+func (m *MockRepository) CreateProductType(ctx context.Context, name model.ProductType) (*model.ProductTypeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductType", ctx, name)
+	ret0, _ := ret[0].(*model.ProductTypeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductType indicates an expected call of CreateProductType.
+func (mr *MockRepositoryMockRecorder) CreateProductType(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductType", reflect.TypeOf((*MockRepository)(nil).CreateProductType), ctx, name)
 }
 
 // CreatePvz mocks base method.
-func (m *MockRepository) CreatePvz(ctx context.Context, city model.City) (*model.Pvz, error) {
+func (m *MockRepository) CreatePvz(ctx context.Context, req model.CreatePvzRequest) (*model.Pvz, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePvz", ctx, city)
+	ret := m.ctrl.Call(m, "CreatePvz", ctx, req)
 	ret0, _ := ret[0].(*model.Pvz)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePvz indicates an expected call of CreatePvz.
-func (mr *MockRepositoryMockRecorder) CreatePvz(ctx, city interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) CreatePvz(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePvz", reflect.TypeOf((*MockRepository)(nil).CreatePvz), ctx, city)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePvz", reflect.TypeOf((*MockRepository)(nil).CreatePvz), ctx, req)
 }
 
 // CreateReception mocks base method.
@@ -110,48 +157,182 @@ func (mr *MockRepositoryMockRecorder) CreateReception(ctx, tx, pvzId interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockRepository)(nil).CreateReception), ctx, tx, pvzId)
 }
 
-// DeleteLastProduct mocks base method.
-func (m *MockRepository) DeleteLastProduct(ctx context.Context, tx v4.Tx, receptionId uuid.UUID) error {
+// CreateReceptionTransition mocks base method.
+func (m *MockRepository) CreateReceptionTransition(ctx context.Context, tx v4.Tx, transition model.ReceptionTransition) (*model.ReceptionTransition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLastProduct", ctx, tx, receptionId)
+	ret := m.ctrl.Call(m, "CreateReceptionTransition", ctx, tx, transition)
+	ret0, _ := ret[0].(*model.ReceptionTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReceptionTransition indicates an expected call of CreateReceptionTransition.
+func (mr *MockRepositoryMockRecorder) CreateReceptionTransition(ctx, tx, transition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReceptionTransition", reflect.TypeOf((*MockRepository)(nil).CreateReceptionTransition), ctx, tx, transition)
+}
+
+// DeleteCity mocks base method.
+func (m *MockRepository) DeleteCity(ctx context.Context, name model.City) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCity", ctx, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// DeleteCity indicates an expected call of DeleteCity.
+func (mr *MockRepositoryMockRecorder) DeleteCity(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCity", reflect.TypeOf((*MockRepository)(nil).DeleteCity), ctx, name)
+}
+
+// DeleteLastProduct mocks base method.
+func (m *MockRepository) DeleteLastProduct(ctx context.Context, tx v4.Tx, receptionId uuid.UUID) (*model.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLastProduct", ctx, tx, receptionId)
+	ret0, _ := ret[0].(*model.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteLastProduct indicates an expected call of DeleteLastProduct.
 func (mr *MockRepositoryMockRecorder) DeleteLastProduct(ctx, tx, receptionId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastProduct", reflect.TypeOf((*MockRepository)(nil).DeleteLastProduct), ctx, tx, receptionId)
 }
 
-// GetCurrentReception mocks base method.
-func (m *MockRepository) GetCurrentReception(ctx context.Context, tx v4.Tx, pvzId uuid.UUID) (*model.Reception, error) {
+// DeleteProduct mocks base method.
+func (m *MockRepository) DeleteProduct(ctx context.Context, tx v4.Tx, receptionId, productId uuid.UUID) (*model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCurrentReception", ctx, tx, pvzId)
-	ret0, _ := ret[0].(*model.Reception)
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, tx, receptionId, productId)
+	ret0, _ := ret[0].(*model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCurrentReception indicates an expected call of GetCurrentReception.
-func (mr *MockRepositoryMockRecorder) GetCurrentReception(ctx, tx, pvzId interface{}) *gomock.Call {
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockRepositoryMockRecorder) DeleteProduct(ctx, tx, receptionId, productId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentReception", reflect.TypeOf((*MockRepository)(nil).GetCurrentReception), ctx, tx, pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockRepository)(nil).DeleteProduct), ctx, tx, receptionId, productId)
 }
 
-// GetProductsInReception mocks base method.
-func (m *MockRepository) GetProductsInReception(ctx context.Context, tx v4.Tx, receptionId uuid.UUID) ([]model.Product, error) {
+// DeleteProductType mocks base method.
+func (m *MockRepository) DeleteProductType(ctx context.Context, name model.ProductType) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsInReception", ctx, tx, receptionId)
-	ret0, _ := ret[0].([]model.Product)
+	ret := m.ctrl.Call(m, "DeleteProductType", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductType indicates an expected call of DeleteProductType.
+func (mr *MockRepositoryMockRecorder) DeleteProductType(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductType", reflect.TypeOf((*MockRepository)(nil).DeleteProductType), ctx, name)
+}
+
+// FindNearestPvz mocks base method.
+func (m *MockRepository) FindNearestPvz(ctx context.Context, query model.NearestPvzQuery) ([]model.PvzDistance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearestPvz", ctx, query)
+	ret0, _ := ret[0].([]model.PvzDistance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearestPvz indicates an expected call of FindNearestPvz.
+func (mr *MockRepositoryMockRecorder) FindNearestPvz(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearestPvz", reflect.TypeOf((*MockRepository)(nil).FindNearestPvz), ctx, query)
+}
+
+// FindProductsByBarcode mocks base method.
+func (m *MockRepository) FindProductsByBarcode(ctx context.Context, barcode string) ([]model.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProductsByBarcode", ctx, barcode)
+	ret0, _ := ret[0].([]model.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProductsByBarcode indicates an expected call of FindProductsByBarcode.
+func (mr *MockRepositoryMockRecorder) FindProductsByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductsByBarcode", reflect.TypeOf((*MockRepository)(nil).FindProductsByBarcode), ctx, barcode)
+}
+
+// GetCatalogItem mocks base method.
+func (m *MockRepository) GetCatalogItem(ctx context.Context, tx v4.Tx, code string) (*model.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItem", ctx, tx, code)
+	ret0, _ := ret[0].(*model.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItem indicates an expected call of GetCatalogItem.
+func (mr *MockRepositoryMockRecorder) GetCatalogItem(ctx, tx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItem", reflect.TypeOf((*MockRepository)(nil).GetCatalogItem), ctx, tx, code)
+}
+
+// GetCatalogItemByBarcode mocks base method.
+func (m *MockRepository) GetCatalogItemByBarcode(ctx context.Context, tx v4.Tx, barcode string) (*model.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItemByBarcode", ctx, tx, barcode)
+	ret0, _ := ret[0].(*model.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItemByBarcode indicates an expected call of GetCatalogItemByBarcode.
+func (mr *MockRepositoryMockRecorder) GetCatalogItemByBarcode(ctx, tx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItemByBarcode", reflect.TypeOf((*MockRepository)(nil).GetCatalogItemByBarcode), ctx, tx, barcode)
+}
+
+// GetCatalogItemsByBarcodes mocks base method.
+func (m *MockRepository) GetCatalogItemsByBarcodes(ctx context.Context, tx v4.Tx, barcodes []string) ([]model.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItemsByBarcodes", ctx, tx, barcodes)
+	ret0, _ := ret[0].([]model.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItemsByBarcodes indicates an expected call of GetCatalogItemsByBarcodes.
+func (mr *MockRepositoryMockRecorder) GetCatalogItemsByBarcodes(ctx, tx, barcodes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItemsByBarcodes", reflect.TypeOf((*MockRepository)(nil).GetCatalogItemsByBarcodes), ctx, tx, barcodes)
+}
+
+// GetCity mocks base method.
+func (m *MockRepository) GetCity(ctx context.Context, name model.City) (*model.CityInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCity", ctx, name)
+	ret0, _ := ret[0].(*model.CityInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductsInReception indicates an expected call of GetProductsInReception.
-func (mr *MockRepositoryMockRecorder) GetProductsInReception(ctx, tx, receptionId interface{}) *gomock.Call {
+// GetCity indicates an expected call of GetCity.
+func (mr *MockRepositoryMockRecorder) GetCity(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsInReception", reflect.TypeOf((*MockRepository)(nil).GetProductsInReception), ctx, tx, receptionId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCity", reflect.TypeOf((*MockRepository)(nil).GetCity), ctx, name)
+}
+
+// GetCurrentReception mocks base method.
+func (m *MockRepository) GetCurrentReception(ctx context.Context, tx v4.Tx, pvzId uuid.UUID) (*model.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentReception", ctx, tx, pvzId)
+	ret0, _ := ret[0].(*model.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentReception indicates an expected call of GetCurrentReception.
+func (mr *MockRepositoryMockRecorder) GetCurrentReception(ctx, tx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentReception", reflect.TypeOf((*MockRepository)(nil).GetCurrentReception), ctx, tx, pvzId)
 }
 
 // GetPvz mocks base method.
@@ -169,44 +350,181 @@ func (mr *MockRepositoryMockRecorder) GetPvz(ctx, tx, pvzId interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvz", reflect.TypeOf((*MockRepository)(nil).GetPvz), ctx, tx, pvzId)
 }
 
-// GetReceptionsForPeriod mocks base method.
-func (m *MockRepository) GetReceptionsForPeriod(ctx context.Context, tx v4.Tx, startDate, endDate time.Time, offset, limit int32) ([]model.Reception, error) {
+// GetPvzInfoForPeriod mocks base method.
+func (m *MockRepository) GetPvzInfoForPeriod(ctx context.Context, tx v4.Tx, filter model.PvzInfoFilter) ([]model.PvzInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzInfoForPeriod", ctx, tx, filter)
+	ret0, _ := ret[0].([]model.PvzInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzInfoForPeriod indicates an expected call of GetPvzInfoForPeriod.
+func (mr *MockRepositoryMockRecorder) GetPvzInfoForPeriod(ctx, tx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzInfoForPeriod", reflect.TypeOf((*MockRepository)(nil).GetPvzInfoForPeriod), ctx, tx, filter)
+}
+
+// GetPvzList mocks base method.
+func (m *MockRepository) GetPvzList(ctx context.Context) ([]model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzList", ctx)
+	ret0, _ := ret[0].([]model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzList indicates an expected call of GetPvzList.
+func (mr *MockRepositoryMockRecorder) GetPvzList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzList", reflect.TypeOf((*MockRepository)(nil).GetPvzList), ctx)
+}
+
+// GetPvzPage mocks base method.
+func (m *MockRepository) GetPvzPage(ctx context.Context, after *model.PvzCursor, limit int32) ([]model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzPage", ctx, after, limit)
+	ret0, _ := ret[0].([]model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzPage indicates an expected call of GetPvzPage.
+func (mr *MockRepositoryMockRecorder) GetPvzPage(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzPage", reflect.TypeOf((*MockRepository)(nil).GetPvzPage), ctx, after, limit)
+}
+
+// GetReception mocks base method.
+func (m *MockRepository) GetReception(ctx context.Context, tx v4.Tx, receptionId uuid.UUID) (*model.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReception", ctx, tx, receptionId)
+	ret0, _ := ret[0].(*model.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReception indicates an expected call of GetReception.
+func (mr *MockRepositoryMockRecorder) GetReception(ctx, tx, receptionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReception", reflect.TypeOf((*MockRepository)(nil).GetReception), ctx, tx, receptionId)
+}
+
+// ListCities mocks base method.
+func (m *MockRepository) ListCities(ctx context.Context) ([]model.CityInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReceptionsForPeriod", ctx, tx, startDate, endDate, offset, limit)
-	ret0, _ := ret[0].([]model.Reception)
+	ret := m.ctrl.Call(m, "ListCities", ctx)
+	ret0, _ := ret[0].([]model.CityInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReceptionsForPeriod indicates an expected call of GetReceptionsForPeriod.
-func (mr *MockRepositoryMockRecorder) GetReceptionsForPeriod(ctx, tx, startDate, endDate, offset, limit interface{}) *gomock.Call {
+// ListCities indicates an expected call of ListCities.
+func (mr *MockRepositoryMockRecorder) ListCities(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionsForPeriod", reflect.TypeOf((*MockRepository)(nil).GetReceptionsForPeriod), ctx, tx, startDate, endDate, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCities", reflect.TypeOf((*MockRepository)(nil).ListCities), ctx)
 }
 
-// RollbackTx mocks base method.
-func (m *MockRepository) RollbackTx(ctx context.Context, tx v4.Tx) {
+// ListProductTypes mocks base method.
+func (m *MockRepository) ListProductTypes(ctx context.Context) ([]model.ProductTypeInfo, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RollbackTx", ctx, tx)
+	ret := m.ctrl.Call(m, "ListProductTypes", ctx)
+	ret0, _ := ret[0].([]model.ProductTypeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RollbackTx indicates an expected call of RollbackTx.
-func (mr *MockRepositoryMockRecorder) RollbackTx(ctx, tx interface{}) *gomock.Call {
+// ListProductTypes indicates an expected call of ListProductTypes.
+func (mr *MockRepositoryMockRecorder) ListProductTypes(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTx", reflect.TypeOf((*MockRepository)(nil).RollbackTx), ctx, tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductTypes", reflect.TypeOf((*MockRepository)(nil).ListProductTypes), ctx)
 }
 
-// UpdateLastReceptionStatus mocks base method.
-func (m *MockRepository) UpdateLastReceptionStatus(ctx context.Context, tx v4.Tx, pvzId uuid.UUID) (*model.Reception, error) {
+// ReceptionClosedWithin mocks base method.
+func (m *MockRepository) ReceptionClosedWithin(ctx context.Context, tx v4.Tx, receptionId uuid.UUID, window time.Duration) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateLastReceptionStatus", ctx, tx, pvzId)
+	ret := m.ctrl.Call(m, "ReceptionClosedWithin", ctx, tx, receptionId, window)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceptionClosedWithin indicates an expected call of ReceptionClosedWithin.
+func (mr *MockRepositoryMockRecorder) ReceptionClosedWithin(ctx, tx, receptionId, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceptionClosedWithin", reflect.TypeOf((*MockRepository)(nil).ReceptionClosedWithin), ctx, tx, receptionId, window)
+}
+
+// RenameProductType mocks base method.
+func (m *MockRepository) RenameProductType(ctx context.Context, name, newName model.ProductType) (*model.ProductTypeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameProductType", ctx, name, newName)
+	ret0, _ := ret[0].(*model.ProductTypeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameProductType indicates an expected call of RenameProductType.
+func (mr *MockRepositoryMockRecorder) RenameProductType(ctx, name, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameProductType", reflect.TypeOf((*MockRepository)(nil).RenameProductType), ctx, name, newName)
+}
+
+// UpdateCity mocks base method.
+func (m *MockRepository) UpdateCity(ctx context.Context, name model.City, req model.UpdateCityRequest) (*model.CityInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCity", ctx, name, req)
+	ret0, _ := ret[0].(*model.CityInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCity indicates an expected call of UpdateCity.
+func (mr *MockRepositoryMockRecorder) UpdateCity(ctx, name, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCity", reflect.TypeOf((*MockRepository)(nil).UpdateCity), ctx, name, req)
+}
+
+// UpdatePvz mocks base method.
+func (m *MockRepository) UpdatePvz(ctx context.Context, pvzId uuid.UUID, req model.UpdatePvzRequest) (*model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePvz", ctx, pvzId, req)
+	ret0, _ := ret[0].(*model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePvz indicates an expected call of UpdatePvz.
+func (mr *MockRepositoryMockRecorder) UpdatePvz(ctx, pvzId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePvz", reflect.TypeOf((*MockRepository)(nil).UpdatePvz), ctx, pvzId, req)
+}
+
+// UpdateReceptionStatus mocks base method.
+func (m *MockRepository) UpdateReceptionStatus(ctx context.Context, tx v4.Tx, receptionId uuid.UUID, status model.ReceptionStatus, actorId string) (*model.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReceptionStatus", ctx, tx, receptionId, status, actorId)
 	ret0, _ := ret[0].(*model.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateLastReceptionStatus indicates an expected call of UpdateLastReceptionStatus.
-func (mr *MockRepositoryMockRecorder) UpdateLastReceptionStatus(ctx, tx, pvzId interface{}) *gomock.Call {
+// UpdateReceptionStatus indicates an expected call of UpdateReceptionStatus.
+func (mr *MockRepositoryMockRecorder) UpdateReceptionStatus(ctx, tx, receptionId, status, actorId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReceptionStatus", reflect.TypeOf((*MockRepository)(nil).UpdateReceptionStatus), ctx, tx, receptionId, status, actorId)
+}
+
+// WithTx mocks base method.
+func (m *MockRepository) WithTx(ctx context.Context, options *v4.TxOptions, fn func(v4.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, options, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockRepositoryMockRecorder) WithTx(ctx, options, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastReceptionStatus", reflect.TypeOf((*MockRepository)(nil).UpdateLastReceptionStatus), ctx, tx, pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockRepository)(nil).WithTx), ctx, options, fn)
 }
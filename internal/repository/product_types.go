@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repo) ListProductTypes(ctx context.Context) ([]model.ProductTypeInfo, error) {
+	rows, err := r.db.ExecQuery(ctx, "SELECT name, created_at FROM product_types ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	types := []model.ProductTypeInfo{}
+	for rows.Next() {
+		var t model.ProductTypeInfo
+		if err := rows.Scan(&t.Name, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return types, nil
+}
+
+func (r *Repo) CreateProductType(ctx context.Context, name model.ProductType) (*model.ProductTypeInfo, error) {
+	var t model.ProductTypeInfo
+	err := r.db.ExecQueryRow(ctx, "INSERT INTO product_types (name, created_at) VALUES ($1, $2) RETURNING name, created_at",
+		name, time.Now()).Scan(&t.Name, &t.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.ErrProductTypeAlreadyExists
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// RenameProductType changes the name of a type. Products and catalog items of
+// the type follow the new name through ON UPDATE CASCADE.
+func (r *Repo) RenameProductType(ctx context.Context, name, newName model.ProductType) (*model.ProductTypeInfo, error) {
+	var t model.ProductTypeInfo
+	err := r.db.ExecQueryRow(ctx, "UPDATE product_types SET name = $2 WHERE name = $1 RETURNING name, created_at",
+		name, newName).Scan(&t.Name, &t.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrProductTypeDoesNotExist
+		}
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.ErrProductTypeAlreadyExists
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// DeleteProductType removes a type that no product or catalog item refers to.
+func (r *Repo) DeleteProductType(ctx context.Context, name model.ProductType) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM product_types WHERE name = $1", name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return errors.ErrProductTypeInUse
+		}
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.ErrProductTypeDoesNotExist
+	}
+	return nil
+}
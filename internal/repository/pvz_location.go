@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"avito2/internal/model"
+	"context"
+	"math"
+)
+
+const earthRadiusMeters = 6371000
+
+// boundingBox returns the latitude and longitude ranges that contain every
+// point within radius metres of the given one. Near the poles or across the
+// antimeridian the longitude range widens to the whole circle.
+func boundingBox(lat, lon, radius float64) (minLat, maxLat, minLon, maxLon float64) {
+	dLat := radius / earthRadiusMeters * 180 / math.Pi
+	minLat, maxLat = lat-dLat, lat+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		return math.Max(minLat, -90), math.Min(maxLat, 90), -180, 180
+	}
+
+	dLon := dLat / math.Cos(lat*math.Pi/180)
+	minLon, maxLon = lon-dLon, lon+dLon
+	if minLon < -180 || maxLon > 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLon, maxLon
+}
+
+// FindNearestPvz returns active pvz within the radius of the point ordered by
+// distance. The bounding box lets the coordinates index cut the candidates
+// before the haversine distance is computed for each of them.
+func (r *Repo) FindNearestPvz(ctx context.Context, query model.NearestPvzQuery) ([]model.PvzDistance, error) {
+	minLat, maxLat, minLon, maxLon := boundingBox(query.Latitude, query.Longitude, query.RadiusMeters)
+
+	rows, err := r.db.ExecQuery(ctx, `SELECT `+pvzColumns+`, distance FROM (
+			SELECT *, 2 * $3::float8 * asin(least(1, sqrt(
+				power(sin(radians(latitude - $1) / 2), 2)
+				+ cos(radians($1)) * cos(radians(latitude)) * power(sin(radians(longitude - $2) / 2), 2)))) AS distance
+			FROM pvz
+			WHERE active AND latitude BETWEEN $4 AND $5 AND longitude BETWEEN $6 AND $7
+		) p
+		WHERE distance <= $8
+		ORDER BY distance, id LIMIT $9`,
+		query.Latitude, query.Longitude, earthRadiusMeters, minLat, maxLat, minLon, maxLon, query.RadiusMeters, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := []model.PvzDistance{}
+	for rows.Next() {
+		var p model.PvzDistance
+		if err := rows.Scan(&p.Id, &p.RegistrationDate, &p.City, &p.Address, &p.Latitude, &p.Longitude,
+			&p.WorkingHours, &p.Active, &p.DeactivatedAt, &p.DistanceMeters); err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BoundingBox(t *testing.T) {
+	t.Parallel()
+
+	t.Run("around moscow", func(t *testing.T) {
+		t.Parallel()
+
+		minLat, maxLat, minLon, maxLon := boundingBox(55.75, 37.62, 10000)
+
+		assert.InDelta(t, 55.66, minLat, 0.01)
+		assert.InDelta(t, 55.84, maxLat, 0.01)
+		assert.InDelta(t, 37.46, minLon, 0.01)
+		assert.InDelta(t, 37.78, maxLon, 0.01)
+	})
+	t.Run("across the antimeridian", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, minLon, maxLon := boundingBox(64.73, 179.5, 100000)
+
+		assert.Equal(t, -180.0, minLon)
+		assert.Equal(t, 180.0, maxLon)
+	})
+	t.Run("near the pole", func(t *testing.T) {
+		t.Parallel()
+
+		minLat, maxLat, minLon, maxLon := boundingBox(89.9, 0, 100000)
+
+		assert.Less(t, minLat, 89.9)
+		assert.Equal(t, 90.0, maxLat)
+		assert.Equal(t, -180.0, minLon)
+		assert.Equal(t, 180.0, maxLon)
+	})
+}
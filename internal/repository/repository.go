@@ -6,93 +6,272 @@ import (
 	"avito2/internal/errors"
 	"avito2/internal/model"
 	"context"
-	"log"
+	stdErrors "errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v4"
 )
 
+// receptionColumns is the select list matching scanReception.
+const receptionColumns = "id, date_time, pvz_id, status, closed_at, coalesce(closed_by, '')"
+
+func scanReception(row pgx.Row) (*model.Reception, error) {
+	var reception model.Reception
+	if err := row.Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status, &reception.ClosedAt, &reception.ClosedBy); err != nil {
+		return nil, err
+	}
+	return &reception, nil
+}
+
+// productColumns is the select list matching scanProduct.
+const productColumns = "id, date_time, type, reception_id, coalesce(barcode, '')"
+
+func scanProduct(row pgx.Row) (*model.Product, error) {
+	var product model.Product
+	if err := row.Scan(&product.Id, &product.DateTime, &product.Type, &product.ReceptionId, &product.Barcode); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// receptionInProgressConstraint is the partial unique index that allows at most
+// one in-progress reception per pvz.
+const receptionInProgressConstraint = "uq_receptions_pvz_id_in_progress"
+
+// productTypeConstraint keeps products.type in product_types. It fires when a
+// type is deleted after the service has validated it.
+const productTypeConstraint = "fk_products_type"
+
+// productInsertError maps a product type deleted concurrently to
+// errors.ErrInvalidProductType.
+func productInsertError(err error) error {
+	var pgErr *pgconn.PgError
+	if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation && pgErr.ConstraintName == productTypeConstraint {
+		return errors.ErrInvalidProductType
+	}
+	return err
+}
+
 type Repo struct {
 	db db.DBops
 }
 
 type Repository interface {
-	BeginTransaction(ctx context.Context, options *pgx.TxOptions) (pgx.Tx, error)
-	RollbackTx(ctx context.Context, tx pgx.Tx)
-	CommitTx(ctx context.Context, tx pgx.Tx)
-	CreatePvz(ctx context.Context, city model.City) (*model.Pvz, error)
+	WithTx(ctx context.Context, options *pgx.TxOptions, fn func(tx pgx.Tx) error) error
+	CreatePvz(ctx context.Context, req model.CreatePvzRequest) (*model.Pvz, error)
 	GetPvz(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Pvz, error)
-	UpdateLastReceptionStatus(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Reception, error)
+	UpdatePvz(ctx context.Context, pvzId uuid.UUID, req model.UpdatePvzRequest) (*model.Pvz, error)
+	FindNearestPvz(ctx context.Context, query model.NearestPvzQuery) ([]model.PvzDistance, error)
+	GetPvzList(ctx context.Context) ([]model.Pvz, error)
+	GetPvzPage(ctx context.Context, after *model.PvzCursor, limit int32) ([]model.Pvz, error)
+	GetReception(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) (*model.Reception, error)
+	UpdateReceptionStatus(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, status model.ReceptionStatus, actorId string) (*model.Reception, error)
+	ReceptionClosedWithin(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, window time.Duration) (bool, error)
+	CreateReceptionTransition(ctx context.Context, tx pgx.Tx, transition model.ReceptionTransition) (*model.ReceptionTransition, error)
 	GetCurrentReception(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Reception, error)
 	CreateReception(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Reception, error)
-	AddProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, productType model.ProductType) (*model.Product, error)
-	DeleteLastProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) error
-	GetReceptionsForPeriod(ctx context.Context, tx pgx.Tx, startDate, endDate time.Time, offset, limit int32) ([]model.Reception, error)
-	GetProductsInReception(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) ([]model.Product, error)
+	AddProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, productType model.ProductType, barcode string) (*model.Product, error)
+	AddProducts(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, items []model.ProductBatchItem) ([]model.Product, error)
+	DeleteLastProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) (*model.Product, error)
+	DeleteProduct(ctx context.Context, tx pgx.Tx, receptionId, productId uuid.UUID) (*model.Product, error)
+	CreateProductDeletion(ctx context.Context, tx pgx.Tx, deletion model.ProductDeletion) error
+	GetPvzInfoForPeriod(ctx context.Context, tx pgx.Tx, filter model.PvzInfoFilter) ([]model.PvzInfo, error)
+	CreateCatalogItem(ctx context.Context, item model.CatalogItem) (*model.CatalogItem, error)
+	GetCatalogItem(ctx context.Context, tx pgx.Tx, code string) (*model.CatalogItem, error)
+	GetCatalogItemByBarcode(ctx context.Context, tx pgx.Tx, barcode string) (*model.CatalogItem, error)
+	GetCatalogItemsByBarcodes(ctx context.Context, tx pgx.Tx, barcodes []string) ([]model.CatalogItem, error)
+	FindProductsByBarcode(ctx context.Context, barcode string) ([]model.ProductLocation, error)
+	ListProductTypes(ctx context.Context) ([]model.ProductTypeInfo, error)
+	CreateProductType(ctx context.Context, name model.ProductType) (*model.ProductTypeInfo, error)
+	RenameProductType(ctx context.Context, name, newName model.ProductType) (*model.ProductTypeInfo, error)
+	DeleteProductType(ctx context.Context, name model.ProductType) error
+	ListCities(ctx context.Context) ([]model.CityInfo, error)
+	GetCity(ctx context.Context, name model.City) (*model.CityInfo, error)
+	CreateCity(ctx context.Context, city model.CityInfo) (*model.CityInfo, error)
+	UpdateCity(ctx context.Context, name model.City, req model.UpdateCityRequest) (*model.CityInfo, error)
+	DeleteCity(ctx context.Context, name model.City) error
 }
 
 func NewRepository(database db.DBops) *Repo {
 	return &Repo{db: database}
 }
-func (r *Repo) BeginTransaction(ctx context.Context, options *pgx.TxOptions) (pgx.Tx, error) {
-	return r.db.BeginTx(ctx, options)
+
+const pvzColumns = "id, registration_date, city, address, latitude, longitude, working_hours, active, deactivated_at"
+
+func scanPvz(row pgx.Row) (*model.Pvz, error) {
+	var pvz model.Pvz
+	if err := row.Scan(&pvz.Id, &pvz.RegistrationDate, &pvz.City, &pvz.Address, &pvz.Latitude, &pvz.Longitude,
+		&pvz.WorkingHours, &pvz.Active, &pvz.DeactivatedAt); err != nil {
+		return nil, err
+	}
+	return &pvz, nil
 }
 
-func (r *Repo) RollbackTx(ctx context.Context, tx pgx.Tx) {
-	if err := tx.Rollback(ctx); err != nil {
-		log.Println("failed to rollback tx wih err:", err)
+func (r *Repo) CreatePvz(ctx context.Context, req model.CreatePvzRequest) (*model.Pvz, error) {
+	regDate := time.Now()
+	return scanPvz(r.db.ExecQueryRow(ctx, `INSERT INTO pvz (registration_date, city, address, latitude, longitude, working_hours)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+pvzColumns,
+		regDate, req.City, req.Address, req.Latitude, req.Longitude, req.WorkingHours))
+}
+
+func (r *Repo) GetPvz(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Pvz, error) {
+	pvz, err := scanPvz(tx.QueryRow(ctx, "SELECT "+pvzColumns+" FROM pvz WHERE id = $1", pvzId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPvzDoesNotExist
+		}
+		return nil, err
 	}
+
+	return pvz, nil
 }
 
-func (r *Repo) CommitTx(ctx context.Context, tx pgx.Tx) {
-	if err := tx.Commit(ctx); err != nil {
-		log.Println("failed to commit tx wih err:", err)
+// UpdatePvz changes the fields of the request that are present. Deactivation
+// time is set when an active pvz is deactivated and cleared on reactivation.
+func (r *Repo) UpdatePvz(ctx context.Context, pvzId uuid.UUID, req model.UpdatePvzRequest) (*model.Pvz, error) {
+	pvz, err := scanPvz(r.db.ExecQueryRow(ctx, `UPDATE pvz SET city = coalesce($2, city), active = coalesce($3, active),
+			deactivated_at = CASE WHEN $3 AND NOT active THEN NULL WHEN NOT $3 AND active THEN $4 ELSE deactivated_at END,
+			address = coalesce($5, address), latitude = coalesce($6, latitude), longitude = coalesce($7, longitude),
+			working_hours = coalesce($8, working_hours)
+		WHERE id = $1 RETURNING `+pvzColumns,
+		pvzId, req.City, req.Active, time.Now(), req.Address, req.Latitude, req.Longitude, req.WorkingHours))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPvzDoesNotExist
+		}
+		return nil, err
 	}
+
+	return pvz, nil
 }
 
-func (r *Repo) CreatePvz(ctx context.Context, city model.City) (*model.Pvz, error) {
-	regDate := time.Now()
-	row := r.db.ExecQueryRow(ctx, "INSERT INTO pvz (registration_date, city) VALUES ($1, $2) RETURNING id, registration_date, city", regDate, city)
+func (r *Repo) GetPvzList(ctx context.Context) ([]model.Pvz, error) {
+	rows, err := r.db.ExecQuery(ctx, "SELECT "+pvzColumns+" FROM pvz ORDER BY registration_date, id")
+	if err != nil {
+		return nil, err
+	}
+	return scanPvzList(rows)
+}
 
-	var pvz model.Pvz
-	if err := row.Scan(&pvz.Id, &pvz.RegistrationDate, &pvz.City); err != nil {
+// GetPvzPage returns up to limit pvz ordered by (registration_date, id),
+// starting strictly after the cursor, or from the first one if it is nil.
+func (r *Repo) GetPvzPage(ctx context.Context, after *model.PvzCursor, limit int32) ([]model.Pvz, error) {
+	var afterDate *time.Time
+	var afterId *uuid.UUID
+	if after != nil {
+		afterDate = &after.RegistrationDate
+		afterId = &after.Id
+	}
+
+	rows, err := r.db.ExecQuery(ctx, `SELECT `+pvzColumns+` FROM pvz
+		WHERE $1::timestamp IS NULL OR (registration_date, id) > ($1::timestamp, $2::uuid)
+		ORDER BY registration_date, id LIMIT $3`, afterDate, afterId, limit)
+	if err != nil {
 		return nil, err
 	}
+	return scanPvzList(rows)
+}
 
-	return &pvz, nil
+func scanPvzList(rows pgx.Rows) ([]model.Pvz, error) {
+	defer rows.Close()
+
+	pvzList := []model.Pvz{}
+	for rows.Next() {
+		pvz, err := scanPvz(rows)
+		if err != nil {
+			return nil, err
+		}
+		pvzList = append(pvzList, *pvz)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pvzList, nil
 }
 
-func (r *Repo) GetPvz(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Pvz, error) {
-	var pvz model.Pvz
-	err := tx.QueryRow(ctx, "SELECT * FROM pvz WHERE id = $1", pvzId).Scan(&pvz.Id, &pvz.City, &pvz.RegistrationDate)
+func (r *Repo) GetReception(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) (*model.Reception, error) {
+	reception, err := scanReception(tx.QueryRow(ctx, "SELECT "+receptionColumns+" FROM receptions WHERE id = $1 FOR UPDATE", receptionId))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, errors.ErrPvzDoesNotExist
+			return nil, errors.ErrReceptionDoesNotExist
 		}
 		return nil, err
 	}
 
-	return &pvz, nil
+	return reception, nil
 }
 
-func (r *Repo) UpdateLastReceptionStatus(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Reception, error) {
-	row := tx.QueryRow(ctx, "UPDATE receptions SET status = $1 WHERE pvz_id = $2 AND status = $3 RETURNING id, date_time, pvz_id, status",
-		model.ReceptionStatusClose, pvzId, model.ReceptionStatusInProgress)
-	var reception model.Reception
-	if err := row.Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status); err != nil {
+// UpdateReceptionStatus sets the reception status. Closing stores the close
+// time and actorId, any other status clears them.
+func (r *Repo) UpdateReceptionStatus(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, status model.ReceptionStatus, actorId string) (*model.Reception, error) {
+	var closedAt *time.Time
+	var closedBy *string
+	if status == model.ReceptionStatusClose {
+		now := time.Now()
+		closedAt = &now
+		closedBy = &actorId
+	}
+
+	reception, err := scanReception(tx.QueryRow(ctx, "UPDATE receptions SET status = $1, closed_at = $2, closed_by = $3 WHERE id = $4 RETURNING "+receptionColumns,
+		status, closedAt, closedBy, receptionId))
+	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, nil
+			return nil, errors.ErrReceptionDoesNotExist
+		}
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == receptionInProgressConstraint {
+			return nil, errors.ErrReceptionInProgressAlreadyExists
 		}
 		return nil, err
 	}
-	return &reception, nil
+
+	return reception, nil
+}
+
+// ReceptionClosedWithin reports whether the reception was closed less than
+// window ago. closed_at has no time zone, so it is compared in the database
+// with the current time written the same way rather than read back into Go.
+func (r *Repo) ReceptionClosedWithin(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, window time.Duration) (bool, error) {
+	var closedWithin bool
+	err := tx.QueryRow(ctx, "SELECT coalesce(closed_at > $2::timestamp - make_interval(secs => $3), false) FROM receptions WHERE id = $1",
+		receptionId, time.Now(), window.Seconds()).Scan(&closedWithin)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, errors.ErrReceptionDoesNotExist
+		}
+		return false, err
+	}
+
+	return closedWithin, nil
+}
+
+func (r *Repo) CreateReceptionTransition(ctx context.Context, tx pgx.Tx, transition model.ReceptionTransition) (*model.ReceptionTransition, error) {
+	var res model.ReceptionTransition
+	var fromStatus *string
+	err := tx.QueryRow(ctx, `INSERT INTO reception_transitions (reception_id, from_status, to_status, actor_id, actor_role, created_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)
+		RETURNING id, reception_id, from_status, to_status, actor_id, actor_role, created_at`,
+		transition.ReceptionId, transition.FromStatus, transition.ToStatus, transition.ActorId, transition.ActorRole, time.Now()).
+		Scan(&res.Id, &res.ReceptionId, &fromStatus, &res.ToStatus, &res.ActorId, &res.ActorRole, &res.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if fromStatus != nil {
+		res.FromStatus = model.ReceptionStatus(*fromStatus)
+	}
+
+	return &res, nil
 }
 
 func (r *Repo) GetCurrentReception(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Reception, error) {
-	var reception model.Reception
-	err := tx.QueryRow(ctx, "SELECT * FROM receptions WHERE pvz_id = $1 AND status = $2 FOR UPDATE",
-		pvzId, model.ReceptionStatusInProgress).Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status)
+	reception, err := scanReception(tx.QueryRow(ctx, "SELECT "+receptionColumns+" FROM receptions WHERE pvz_id = $1 AND status = $2 FOR UPDATE",
+		pvzId, model.ReceptionStatusInProgress))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -100,91 +279,228 @@ func (r *Repo) GetCurrentReception(ctx context.Context, tx pgx.Tx, pvzId uuid.UU
 		return nil, err
 	}
 
-	return &reception, nil
+	return reception, nil
 }
 
 func (r *Repo) CreateReception(ctx context.Context, tx pgx.Tx, pvzId uuid.UUID) (*model.Reception, error) {
 	dateTime := time.Now()
-	var reception model.Reception
-	err := tx.QueryRow(ctx, "INSERT INTO receptions (date_time, pvz_id, status) VALUES ($1, $2, $3) RETURNING id, date_time, pvz_id, status",
-		dateTime, pvzId, model.ReceptionStatusInProgress).Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status)
-
+	reception, err := scanReception(tx.QueryRow(ctx, "INSERT INTO receptions (date_time, pvz_id, status) VALUES ($1, $2, $3) RETURNING "+receptionColumns,
+		dateTime, pvzId, model.ReceptionStatusInProgress))
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation && pgErr.ConstraintName == receptionInProgressConstraint {
+			return nil, errors.ErrReceptionInProgressAlreadyExists
+		}
 		return nil, err
 	}
 
-	return &reception, nil
+	return reception, nil
 }
 
-func (r *Repo) AddProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, productType model.ProductType) (*model.Product, error) {
+func (r *Repo) AddProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, productType model.ProductType, barcode string) (*model.Product, error) {
 	dateTime := time.Now()
-	var product model.Product
-	err := tx.QueryRow(ctx, "INSERT INTO products (date_time, type, reception_id) VALUES ($1, $2, $3) RETURNING id, date_time, type, reception_id",
-		dateTime, productType, receptionId).Scan(&product.Id, &product.DateTime, &product.Type, &product.ReceptionId)
+	product, err := scanProduct(tx.QueryRow(ctx, "INSERT INTO products (date_time, type, reception_id, barcode) VALUES ($1, $2, $3, NULLIF($4, '')) RETURNING "+productColumns,
+		dateTime, productType, receptionId, barcode))
+	if err != nil {
+		return nil, productInsertError(err)
+	}
+
+	return product, nil
+}
+
+// AddProducts inserts the items with one statement. Each item gets its own
+// date_time one microsecond after the previous one, so the items keep their
+// order and DeleteLastProduct removes the last of them first.
+func (r *Repo) AddProducts(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, items []model.ProductBatchItem) ([]model.Product, error) {
+	types := make([]string, len(items))
+	barcodes := make([]string, len(items))
+	for i, item := range items {
+		types[i] = string(item.Type)
+		barcodes[i] = item.Barcode
+	}
 
+	rows, err := tx.Query(ctx, `INSERT INTO products (date_time, type, reception_id, barcode)
+		SELECT $1::timestamp + (t.ord - 1) * interval '1 microsecond', t.type, $2, NULLIF(t.barcode, '')
+		FROM unnest($3::varchar[], $4::varchar[]) WITH ORDINALITY AS t(type, barcode, ord)
+		RETURNING `+productColumns,
+		time.Now(), receptionId, types, barcodes)
 	if err != nil {
-		return nil, err
+		return nil, productInsertError(err)
 	}
+	defer rows.Close()
 
-	return &product, nil
+	products := make([]model.Product, 0, len(items))
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, productInsertError(err)
+	}
+
+	// RETURNING does not guarantee the order of the input rows
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].DateTime.Before(products[j].DateTime)
+	})
+	return products, nil
 }
 
-func (r *Repo) DeleteLastProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) error {
-	commandTag, err := tx.Exec(ctx, "DELETE FROM products WHERE id = (SELECT id FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1)", receptionId)
+func (r *Repo) DeleteLastProduct(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) (*model.Product, error) {
+	product, err := scanProduct(tx.QueryRow(ctx, "DELETE FROM products WHERE id = (SELECT id FROM products WHERE reception_id = $1 ORDER BY date_time DESC LIMIT 1) RETURNING "+productColumns,
+		receptionId))
 	if err != nil {
-		return err
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNoProductToDelete
+		}
+		return nil, err
 	}
 
-	if commandTag.RowsAffected() == 0 {
-		return errors.ErrNoProductToDelete
+	return product, nil
+}
+
+// DeleteProduct removes the product only if it belongs to the reception, so a
+// product id from another reception is reported as missing.
+func (r *Repo) DeleteProduct(ctx context.Context, tx pgx.Tx, receptionId, productId uuid.UUID) (*model.Product, error) {
+	product, err := scanProduct(tx.QueryRow(ctx, "DELETE FROM products WHERE id = $1 AND reception_id = $2 RETURNING "+productColumns,
+		productId, receptionId))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrProductDoesNotExist
+		}
+		return nil, err
 	}
-	return nil
+
+	return product, nil
+}
+
+func (r *Repo) CreateProductDeletion(ctx context.Context, tx pgx.Tx, deletion model.ProductDeletion) error {
+	_, err := tx.Exec(ctx, `INSERT INTO product_deletions (product_id, product_type, product_date_time, reception_id, actor_id, actor_role, deleted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		deletion.ProductId, deletion.ProductType, deletion.ProductDateTime, deletion.ReceptionId, deletion.ActorId, deletion.ActorRole, time.Now())
+	return err
 }
 
-func (r *Repo) GetReceptionsForPeriod(ctx context.Context, tx pgx.Tx, startDate, endDate time.Time, offset, limit int32) ([]model.Reception, error) {
-	rows, err := tx.Query(ctx, "SELECT * FROM receptions WHERE date_time BETWEEN $1 AND $2 ORDER BY date_time DESC LIMIT $3 OFFSET $4 FOR UPDATE", startDate, endDate, limit, offset)
+// GetPvzInfoForPeriod loads a page of pvz ordered by registration date and, with
+// one more query each, their receptions within the period and the products of
+// those receptions. Unless filter.IncludeEmpty is set, pvz without receptions in
+// the period are skipped. When filter.Cursor is set the page starts right after
+// the cursor keyset and filter.Page is ignored. filter.Status and the duration
+// bounds apply to receptions both when picking pvz and when loading receptions.
+func (r *Repo) GetPvzInfoForPeriod(ctx context.Context, tx pgx.Tx, filter model.PvzInfoFilter) ([]model.PvzInfo, error) {
+	offset := (filter.Page - 1) * filter.Limit
+	var cursorDate *time.Time
+	var cursorId *uuid.UUID
+	if filter.Cursor != nil {
+		offset = 0
+		cursorDate = &filter.Cursor.RegistrationDate
+		cursorId = &filter.Cursor.Id
+	}
+	var status *model.ReceptionStatus
+	if filter.Status != "" {
+		status = &filter.Status
+	}
+	minSeconds := durationSeconds(filter.MinDuration)
+	maxSeconds := durationSeconds(filter.MaxDuration)
+
+	rows, err := tx.Query(ctx, `SELECT `+pvzColumns+` FROM pvz p
+		WHERE ($3 OR EXISTS (SELECT 1 FROM receptions r WHERE r.pvz_id = p.id AND r.date_time BETWEEN $1 AND $2
+				AND ($8::varchar IS NULL OR r.status = $8)
+				AND ($9::float8 IS NULL OR extract(epoch FROM r.closed_at - r.date_time) >= $9)
+				AND ($10::float8 IS NULL OR extract(epoch FROM r.closed_at - r.date_time) <= $10)))
+			AND ($6::timestamp IS NULL OR (p.registration_date, p.id) < ($6::timestamp, $7::uuid))
+		ORDER BY p.registration_date DESC, p.id DESC LIMIT $4 OFFSET $5`,
+		filter.StartDate, filter.EndDate, filter.IncludeEmpty, filter.Limit, offset, cursorDate, cursorId, status, minSeconds, maxSeconds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	receptions := []model.Reception{}
+	pvzInfoList := []model.PvzInfo{}
+	pvzIndex := map[uuid.UUID]int{}
+	pvzIds := []string{}
 	for rows.Next() {
-		var reception model.Reception
-		err := rows.Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status)
+		pvz, err := scanPvz(rows)
 		if err != nil {
 			return nil, err
 		}
-		receptions = append(receptions, reception)
+
+		pvzIndex[pvz.Id] = len(pvzInfoList)
+		pvzIds = append(pvzIds, pvz.Id.String())
+		pvzInfoList = append(pvzInfoList, model.PvzInfo{Pvz: *pvz, Receptions: []model.ReceptionInfo{}})
 	}
 
-	if rows.Err() != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return receptions, nil
-}
+	if len(pvzIds) == 0 {
+		return pvzInfoList, nil
+	}
 
-func (r *Repo) GetProductsInReception(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) ([]model.Product, error) {
-	rows, err := tx.Query(ctx, "SELECT * FROM products WHERE reception_id = $1 FOR UPDATE", receptionId)
+	rows, err = tx.Query(ctx, `SELECT `+receptionColumns+` FROM receptions r
+		WHERE pvz_id = ANY($1::uuid[]) AND date_time BETWEEN $2 AND $3
+			AND ($4::varchar IS NULL OR r.status = $4)
+			AND ($5::float8 IS NULL OR extract(epoch FROM r.closed_at - r.date_time) >= $5)
+			AND ($6::float8 IS NULL OR extract(epoch FROM r.closed_at - r.date_time) <= $6)
+		ORDER BY date_time DESC, id DESC`, pvzIds, filter.StartDate, filter.EndDate, status, minSeconds, maxSeconds)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	products := []model.Product{}
+	receptionIndex := map[string][2]int{}
+	receptionIds := []string{}
 	for rows.Next() {
-		var product model.Product
-		err := rows.Scan(&product.Id, &product.DateTime, &product.Type, &product.ReceptionId)
+		reception, err := scanReception(rows)
 		if err != nil {
 			return nil, err
 		}
-		products = append(products, product)
+
+		i := pvzIndex[reception.PvzId]
+		receptionIndex[reception.Id.String()] = [2]int{i, len(pvzInfoList[i].Receptions)}
+		receptionIds = append(receptionIds, reception.Id.String())
+		pvzInfoList[i].Receptions = append(pvzInfoList[i].Receptions, model.ReceptionInfo{Reception: *reception, Products: []model.Product{}})
 	}
 
-	if rows.Err() != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return products, nil
+	if len(receptionIds) == 0 {
+		return pvzInfoList, nil
+	}
+
+	rows, err = tx.Query(ctx, "SELECT "+productColumns+" FROM products WHERE reception_id = ANY($1::uuid[]) ORDER BY date_time, id", receptionIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		idx := receptionIndex[product.ReceptionId]
+		receptionInfo := &pvzInfoList[idx[0]].Receptions[idx[1]]
+		receptionInfo.Products = append(receptionInfo.Products, *product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pvzInfoList, nil
+}
+
+func durationSeconds(d *time.Duration) *float64 {
+	if d == nil {
+		return nil
+	}
+	seconds := d.Seconds()
+	return &seconds
 }
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const refreshTokenColumns = "id, family_id, user_id, role, expires_at, created_at, revoked_at"
+
+func scanRefreshToken(row pgx.Row) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := row.Scan(&token.Id, &token.FamilyId, &token.UserId, &token.Role, &token.ExpiresAt, &token.CreatedAt, &token.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *UserRepo) CreateRefreshToken(ctx context.Context, tokenHash string, token model.RefreshToken) (*model.RefreshToken, error) {
+	return scanRefreshToken(r.db.ExecQueryRow(ctx, `INSERT INTO refresh_tokens (token_hash, family_id, user_id, role, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+refreshTokenColumns,
+		tokenHash, token.FamilyId, token.UserId, token.Role, token.ExpiresAt, token.CreatedAt))
+}
+
+// RotateRefreshToken revokes the live token with tokenHash and issues newHash
+// in its family in one statement, so that a token can be exchanged only once.
+// It returns errors.ErrInvalidRefreshToken if the token is unknown, expired or
+// already revoked.
+func (r *UserRepo) RotateRefreshToken(ctx context.Context, tokenHash, newHash string, now, expiresAt time.Time) (*model.RefreshToken, error) {
+	token, err := scanRefreshToken(r.db.ExecQueryRow(ctx, `WITH old AS (
+			UPDATE refresh_tokens SET revoked_at = $3
+			WHERE token_hash = $1 AND revoked_at IS NULL AND expires_at > $3
+			RETURNING family_id, user_id, role
+		)
+		INSERT INTO refresh_tokens (token_hash, family_id, user_id, role, expires_at, created_at)
+		SELECT $2, family_id, user_id, role, $4, $3 FROM old
+		RETURNING `+refreshTokenColumns,
+		tokenHash, newHash, now, expiresAt))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// GetRefreshToken returns the token with tokenHash, or nil if there is none.
+func (r *UserRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	token, err := scanRefreshToken(r.db.ExecQueryRow(ctx, "SELECT "+refreshTokenColumns+" FROM refresh_tokens WHERE token_hash = $1", tokenHash))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// RevokeRefreshTokenFamily revokes every token issued from the same login as
+// the token with tokenHash. Unknown tokens are ignored.
+func (r *UserRepo) RevokeRefreshTokenFamily(ctx context.Context, tokenHash string, now time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = $2
+		WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1) AND revoked_at IS NULL`,
+		tokenHash, now)
+	return err
+}
+
+func (r *UserRepo) RevokeAccessToken(ctx context.Context, token model.RevokedToken) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING",
+		token.Id, token.ExpiresAt)
+	return err
+}
+
+// ListRevokedAccessTokens returns the revoked access tokens that have not
+// expired yet; expired ones are rejected by their exp claim anyway.
+func (r *UserRepo) ListRevokedAccessTokens(ctx context.Context, now time.Time) ([]model.RevokedToken, error) {
+	rows, err := r.db.ExecQuery(ctx, "SELECT jti, expires_at FROM revoked_tokens WHERE expires_at > $1", now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []model.RevokedToken{}
+	for rows.Next() {
+		var token model.RevokedToken
+		if err := rows.Scan(&token.Id, &token.ExpiresAt); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// DeleteExpiredTokens removes the refresh tokens and the revoked access token
+// ids that expired before now. Neither can be used any more: an expired
+// refresh token is rejected without being looked at, and an expired access
+// token fails its exp check. It returns the number of deleted rows.
+func (r *UserRepo) DeleteExpiredTokens(ctx context.Context, now time.Time) (int64, error) {
+	refresh, err := r.db.Exec(ctx, "DELETE FROM refresh_tokens WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+	revoked, err := r.db.Exec(ctx, "DELETE FROM revoked_tokens WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+	return refresh.RowsAffected() + revoked.RowsAffected(), nil
+}
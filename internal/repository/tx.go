@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"avito2/internal/logger"
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	txMaxAttempts = 5
+	txBaseBackoff = 10 * time.Millisecond
+	txMaxBackoff  = 200 * time.Millisecond
+)
+
+// WithTx runs fn in a transaction started with options and commits it when fn
+// returns nil. The transaction is rolled back if fn returns an error or
+// panics. Serialization failures and deadlocks, whether raised by fn or by the
+// commit, restart the whole transaction up to txMaxAttempts times with a
+// jittered exponential backoff, so fn must not keep side effects outside of tx.
+func (r *Repo) WithTx(ctx context.Context, options *pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		err := r.runTx(ctx, options, fn)
+		if err == nil || !isRetryableTxError(err) || attempt == txMaxAttempts {
+			return err
+		}
+
+		backoff := txBackoff(attempt)
+		logger.FromContext(ctx).Warn("retrying tx", "attempt", attempt, "backoff", backoff.String(), "err", err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *Repo) runTx(ctx context.Context, options *pgx.TxOptions, fn func(tx pgx.Tx) error) (err error) {
+	tx, err := r.db.BeginTx(ctx, options)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to begin tx", "err", err)
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if rbErr := tx.Rollback(ctx); rbErr != nil && !stdErrors.Is(rbErr, pgx.ErrTxClosed) {
+			logger.FromContext(ctx).Error("failed to rollback tx", "err", rbErr)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	committed = true
+	if err := tx.Commit(ctx); err != nil {
+		logger.FromContext(ctx).Error("failed to commit tx", "err", err)
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !stdErrors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgerrcode.SerializationFailure || pgErr.Code == pgerrcode.DeadlockDetected
+}
+
+func txBackoff(attempt int) time.Duration {
+	backoff := txBaseBackoff << (attempt - 1)
+	if backoff > txMaxBackoff {
+		backoff = txMaxBackoff
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
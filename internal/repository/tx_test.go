@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsRetryableTxError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: pgerrcode.SerializationFailure}, true},
+		{"deadlock", &pgconn.PgError{Code: pgerrcode.DeadlockDetected}, true},
+		{"wrapped commit failure", fmt.Errorf("commit tx: %w", &pgconn.PgError{Code: pgerrcode.SerializationFailure}), true},
+		{"unique violation", &pgconn.PgError{Code: pgerrcode.UniqueViolation}, false},
+		{"not a pg error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, isRetryableTxError(tt.err))
+		})
+	}
+}
+
+func Test_TxBackoff(t *testing.T) {
+	t.Parallel()
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		backoff := txBackoff(attempt)
+		assert.Positive(t, backoff)
+		assert.LessOrEqual(t, backoff, txMaxBackoff)
+	}
+}
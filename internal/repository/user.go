@@ -0,0 +1,66 @@
+//go:generate mockgen -source ./user.go -destination=./mocks/user.go -package=mock_repository
+package repository
+
+import (
+	"avito2/internal/db"
+	"avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	stdErrors "errors"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+)
+
+type UserRepo struct {
+	db db.DBops
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, email, passwordHash string, role model.Role) (*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	CreateRefreshToken(ctx context.Context, tokenHash string, token model.RefreshToken) (*model.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, tokenHash, newHash string, now, expiresAt time.Time) (*model.RefreshToken, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, tokenHash string, now time.Time) error
+	RevokeAccessToken(ctx context.Context, token model.RevokedToken) error
+	ListRevokedAccessTokens(ctx context.Context, now time.Time) ([]model.RevokedToken, error)
+	DeleteExpiredTokens(ctx context.Context, now time.Time) (int64, error)
+}
+
+func NewUserRepository(database db.DBops) *UserRepo {
+	return &UserRepo{db: database}
+}
+
+func (r *UserRepo) CreateUser(ctx context.Context, email, passwordHash string, role model.Role) (*model.User, error) {
+	createdAt := time.Now()
+	row := r.db.ExecQueryRow(ctx, "INSERT INTO users (email, password_hash, role, created_at) VALUES ($1, $2, $3, $4) RETURNING id, email, password_hash, role, created_at",
+		email, passwordHash, role, createdAt)
+
+	var user model.User
+	if err := row.Scan(&user.Id, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if stdErrors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, errors.ErrUserAlreadyExists
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	err := r.db.ExecQueryRow(ctx, "SELECT id, email, password_hash, role, created_at FROM users WHERE email = $1", email).
+		Scan(&user.Id, &user.Email, &user.PasswordHash, &user.Role, &user.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/model"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+func (s *Svc) CreateCatalogItem(ctx context.Context, item model.CatalogItem) (*model.CatalogItem, error) {
+	exists, err := s.productTypeExists(ctx, item.Type)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.WithDetails(errors.ErrInvalidCatalogItem, map[string]any{
+			"field": "type",
+		})
+	}
+
+	res, err := s.repo.CreateCatalogItem(ctx, item)
+	if err != nil {
+		if err != errors.ErrCatalogItemAlreadyExists {
+			logger.FromContext(ctx).Error("failed to create catalog item", "err", err)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetCatalogItem finds the catalog item by its barcode or SKU.
+func (s *Svc) GetCatalogItem(ctx context.Context, code string) (*model.CatalogItem, error) {
+	var item *model.CatalogItem
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadOnly,
+	}, func(tx pgx.Tx) error {
+		var err error
+		item, err = s.repo.GetCatalogItem(ctx, tx, code)
+		if err != nil && err != errors.ErrCatalogItemDoesNotExist {
+			logger.FromContext(ctx).Error("failed to get catalog item", "err", err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *Svc) FindProductsByBarcode(ctx context.Context, barcode string) ([]model.ProductLocation, error) {
+	locations, err := s.repo.FindProductsByBarcode(ctx, barcode)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to find products by barcode", "err", err)
+		return nil, err
+	}
+	return locations, nil
+}
+
+// catalogProductType resolves the product type of a barcode. A type given by
+// the client must agree with the catalog.
+func (s *Svc) catalogProductType(ctx context.Context, tx pgx.Tx, barcode string, productType model.ProductType) (model.ProductType, error) {
+	item, err := s.repo.GetCatalogItemByBarcode(ctx, tx, barcode)
+	if err != nil {
+		if err != errors.ErrCatalogItemDoesNotExist {
+			logger.FromContext(ctx).Error("failed to get catalog item", "err", err)
+		}
+		return "", err
+	}
+
+	if productType != "" && productType != item.Type {
+		return "", errors.WithDetails(errors.ErrInvalidProductType, map[string]any{
+			"catalog_type": item.Type,
+		})
+	}
+	return item.Type, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateCatalogItem(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		item = model.CatalogItem{Barcode: "4601234567893", Sku: "SHOE-42", Name: "Кеды", Type: model.ProductTypeShoes, WeightGrams: 800, LengthMm: 320, WidthMm: 200, HeightMm: 120}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().CreateCatalogItem(gomock.Any(), item).Return(&item, nil)
+
+		res, err := s.svc.CreateCatalogItem(ctx, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, &item, res)
+	})
+	t.Run("already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().CreateCatalogItem(gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCatalogItemAlreadyExists)
+
+		_, err := s.svc.CreateCatalogItem(ctx, item)
+
+		require.ErrorIs(t, err, customErrors.ErrCatalogItemAlreadyExists)
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		unknown := item
+		unknown.Type = "книги"
+
+		_, err := s.svc.CreateCatalogItem(ctx, unknown)
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidCatalogItem)
+	})
+}
+
+func Test_GetCatalogItem(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		item := &model.CatalogItem{Barcode: "4601234567893", Sku: "SHOE-42"}
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetCatalogItem(gomock.Any(), gomock.Any(), "SHOE-42").Return(item, nil)
+
+		res, err := s.svc.GetCatalogItem(ctx, "SHOE-42")
+
+		require.NoError(t, err)
+		assert.Equal(t, item, res)
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetCatalogItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCatalogItemDoesNotExist)
+
+		_, err := s.svc.GetCatalogItem(ctx, "test")
+
+		require.ErrorIs(t, err, customErrors.ErrCatalogItemDoesNotExist)
+	})
+}
+
+func Test_FindProductsByBarcode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		locations := []model.ProductLocation{{Barcode: "4601234567893", City: model.CityKazan}}
+		s.mockRepo.EXPECT().FindProductsByBarcode(gomock.Any(), "4601234567893").Return(locations, nil)
+
+		res, err := s.svc.FindProductsByBarcode(ctx, "4601234567893")
+
+		require.NoError(t, err)
+		assert.Equal(t, locations, res)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockRepo.EXPECT().FindProductsByBarcode(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.FindProductsByBarcode(ctx, "4601234567893")
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
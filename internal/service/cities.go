@@ -0,0 +1,63 @@
+package service
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/model"
+	"context"
+)
+
+func (s *Svc) ListCities(ctx context.Context) ([]model.CityInfo, error) {
+	cities, err := s.repo.ListCities(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to list cities", "err", err)
+		return nil, err
+	}
+	return cities, nil
+}
+
+func (s *Svc) CreateCity(ctx context.Context, city model.CityInfo) (*model.CityInfo, error) {
+	if field := city.InvalidField(); field != "" {
+		return nil, errors.WithDetails(errors.ErrInvalidCity, map[string]any{
+			"field": field,
+		})
+	}
+
+	res, err := s.repo.CreateCity(ctx, city)
+	if err != nil {
+		if err != errors.ErrCityAlreadyExists {
+			logger.FromContext(ctx).Error("failed to create city", "err", err)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateCity changes the region, timezone or active flag of a city.
+func (s *Svc) UpdateCity(ctx context.Context, name model.City, req model.UpdateCityRequest) (*model.CityInfo, error) {
+	if field := req.InvalidField(); field != "" {
+		return nil, errors.WithDetails(errors.ErrInvalidCity, map[string]any{
+			"field": field,
+		})
+	}
+
+	res, err := s.repo.UpdateCity(ctx, name, req)
+	if err != nil {
+		if err != errors.ErrCityDoesNotExist {
+			logger.FromContext(ctx).Error("failed to update city", "err", err)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Svc) DeleteCity(ctx context.Context, name model.City) error {
+	err := s.repo.DeleteCity(ctx, name)
+	if err != nil {
+		if err != errors.ErrCityDoesNotExist && err != errors.ErrCityInUse {
+			logger.FromContext(ctx).Error("failed to delete city", "err", err)
+		}
+		return err
+	}
+	return nil
+}
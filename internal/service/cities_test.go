@@ -0,0 +1,134 @@
+package service
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateCity(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		city = model.CityInfo{Name: "Новосибирск", Region: "Новосибирская область", Timezone: "Asia/Novosibirsk", Active: true}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().CreateCity(gomock.Any(), city).Return(&city, nil)
+
+		res, err := s.svc.CreateCity(ctx, city)
+
+		require.NoError(t, err)
+		assert.Equal(t, &city, res)
+	})
+	t.Run("invalid timezone", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		invalid := city
+		invalid.Timezone = "Asia/Atlantis"
+
+		_, err := s.svc.CreateCity(ctx, invalid)
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidCity)
+		var detailed *customErrors.DetailedError
+		require.ErrorAs(t, err, &detailed)
+		assert.Equal(t, "timezone", detailed.Details["field"])
+	})
+	t.Run("already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().CreateCity(gomock.Any(), city).Return(nil, customErrors.ErrCityAlreadyExists)
+
+		_, err := s.svc.CreateCity(ctx, city)
+
+		require.ErrorIs(t, err, customErrors.ErrCityAlreadyExists)
+	})
+}
+
+func Test_UpdateCity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inactive := false
+
+	t.Run("deactivate", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		req := model.UpdateCityRequest{Active: &inactive}
+		s.mockRepo.EXPECT().UpdateCity(gomock.Any(), model.CityKazan, req).Return(&model.CityInfo{Name: model.CityKazan}, nil)
+
+		res, err := s.svc.UpdateCity(ctx, model.CityKazan, req)
+
+		require.NoError(t, err)
+		assert.False(t, res.Active)
+	})
+	t.Run("invalid region", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		empty := ""
+
+		_, err := s.svc.UpdateCity(ctx, model.CityKazan, model.UpdateCityRequest{Region: &empty})
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidCity)
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().UpdateCity(gomock.Any(), model.City("test"), gomock.Any()).Return(nil, customErrors.ErrCityDoesNotExist)
+
+		_, err := s.svc.UpdateCity(ctx, "test", model.UpdateCityRequest{Active: &inactive})
+
+		require.ErrorIs(t, err, customErrors.ErrCityDoesNotExist)
+	})
+}
+
+func Test_DeleteCity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("in use", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().DeleteCity(gomock.Any(), model.CityMoscow).Return(customErrors.ErrCityInUse)
+
+		err := s.svc.DeleteCity(ctx, model.CityMoscow)
+
+		require.ErrorIs(t, err, customErrors.ErrCityInUse)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("failed to delete city")
+		s.mockRepo.EXPECT().DeleteCity(gomock.Any(), model.CityMoscow).Return(dbErr)
+
+		err := s.svc.DeleteCity(ctx, model.CityMoscow)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
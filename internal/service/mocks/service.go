@@ -8,7 +8,6 @@ import (
 	model "avito2/internal/model"
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -38,90 +37,387 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // AddProduct mocks base method.
-func (m *MockService) AddProduct(ctx context.Context, pvzId uuid.UUID, productType model.ProductType) (*model.Product, error) {
+func (m *MockService) AddProduct(ctx context.Context, pvzId uuid.UUID, productType model.ProductType, barcode string) (*model.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProduct", ctx, pvzId, productType)
+	ret := m.ctrl.Call(m, "AddProduct", ctx, pvzId, productType, barcode)
 	ret0, _ := ret[0].(*model.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddProduct indicates an expected call of AddProduct.
-func (mr *MockServiceMockRecorder) AddProduct(ctx, pvzId, productType interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) AddProduct(ctx, pvzId, productType, barcode interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockService)(nil).AddProduct), ctx, pvzId, productType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockService)(nil).AddProduct), ctx, pvzId, productType, barcode)
+}
+
+// AddProducts mocks base method.
+func (m *MockService) AddProducts(ctx context.Context, pvzId uuid.UUID, items []model.ProductBatchItem) ([]model.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProducts", ctx, pvzId, items)
+	ret0, _ := ret[0].([]model.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddProducts indicates an expected call of AddProducts.
+func (mr *MockServiceMockRecorder) AddProducts(ctx, pvzId, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProducts", reflect.TypeOf((*MockService)(nil).AddProducts), ctx, pvzId, items)
+}
+
+// CancelReception mocks base method.
+func (m *MockService) CancelReception(ctx context.Context, receptionId uuid.UUID, actor model.Actor) (*model.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReception", ctx, receptionId, actor)
+	ret0, _ := ret[0].(*model.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReception indicates an expected call of CancelReception.
+func (mr *MockServiceMockRecorder) CancelReception(ctx, receptionId, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReception", reflect.TypeOf((*MockService)(nil).CancelReception), ctx, receptionId, actor)
 }
 
 // CloseLastReception mocks base method.
-func (m *MockService) CloseLastReception(ctx context.Context, pvzId uuid.UUID) (*model.Reception, error) {
+func (m *MockService) CloseLastReception(ctx context.Context, pvzId uuid.UUID, actor model.Actor) (*model.Reception, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CloseLastReception", ctx, pvzId)
+	ret := m.ctrl.Call(m, "CloseLastReception", ctx, pvzId, actor)
 	ret0, _ := ret[0].(*model.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CloseLastReception indicates an expected call of CloseLastReception.
-func (mr *MockServiceMockRecorder) CloseLastReception(ctx, pvzId interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CloseLastReception(ctx, pvzId, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseLastReception", reflect.TypeOf((*MockService)(nil).CloseLastReception), ctx, pvzId, actor)
+}
+
+// CreateCatalogItem mocks base method.
+func (m *MockService) CreateCatalogItem(ctx context.Context, item model.CatalogItem) (*model.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCatalogItem", ctx, item)
+	ret0, _ := ret[0].(*model.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCatalogItem indicates an expected call of CreateCatalogItem.
+func (mr *MockServiceMockRecorder) CreateCatalogItem(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCatalogItem", reflect.TypeOf((*MockService)(nil).CreateCatalogItem), ctx, item)
+}
+
+// CreateCity mocks base method.
+func (m *MockService) CreateCity(ctx context.Context, city model.CityInfo) (*model.CityInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCity", ctx, city)
+	ret0, _ := ret[0].(*model.CityInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCity indicates an expected call of CreateCity.
+func (mr *MockServiceMockRecorder) CreateCity(ctx, city interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCity", reflect.TypeOf((*MockService)(nil).CreateCity), ctx, city)
+}
+
+// CreateProductType mocks base method.
+func (m *MockService) CreateProductType(ctx context.Context, name model.ProductType) (*model.ProductTypeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductType", ctx, name)
+	ret0, _ := ret[0].(*model.ProductTypeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductType indicates an expected call of CreateProductType.
+func (mr *MockServiceMockRecorder) CreateProductType(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseLastReception", reflect.TypeOf((*MockService)(nil).CloseLastReception), ctx, pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductType", reflect.TypeOf((*MockService)(nil).CreateProductType), ctx, name)
 }
 
 // CreatePvz mocks base method.
-func (m *MockService) CreatePvz(ctx context.Context, city model.City) (*model.Pvz, error) {
+func (m *MockService) CreatePvz(ctx context.Context, req model.CreatePvzRequest) (*model.Pvz, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePvz", ctx, city)
+	ret := m.ctrl.Call(m, "CreatePvz", ctx, req)
 	ret0, _ := ret[0].(*model.Pvz)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePvz indicates an expected call of CreatePvz.
-func (mr *MockServiceMockRecorder) CreatePvz(ctx, city interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreatePvz(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePvz", reflect.TypeOf((*MockService)(nil).CreatePvz), ctx, city)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePvz", reflect.TypeOf((*MockService)(nil).CreatePvz), ctx, req)
 }
 
 // CreateReception mocks base method.
-func (m *MockService) CreateReception(ctx context.Context, pvzId uuid.UUID) (*model.Reception, error) {
+func (m *MockService) CreateReception(ctx context.Context, pvzId uuid.UUID, actor model.Actor) (*model.Reception, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateReception", ctx, pvzId)
+	ret := m.ctrl.Call(m, "CreateReception", ctx, pvzId, actor)
 	ret0, _ := ret[0].(*model.Reception)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateReception indicates an expected call of CreateReception.
-func (mr *MockServiceMockRecorder) CreateReception(ctx, pvzId interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateReception(ctx, pvzId, actor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockService)(nil).CreateReception), ctx, pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockService)(nil).CreateReception), ctx, pvzId, actor)
+}
+
+// DeleteCity mocks base method.
+func (m *MockService) DeleteCity(ctx context.Context, name model.City) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCity", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCity indicates an expected call of DeleteCity.
+func (mr *MockServiceMockRecorder) DeleteCity(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCity", reflect.TypeOf((*MockService)(nil).DeleteCity), ctx, name)
 }
 
 // DeleteLastProduct mocks base method.
-func (m *MockService) DeleteLastProduct(ctx context.Context, pvzId uuid.UUID) error {
+func (m *MockService) DeleteLastProduct(ctx context.Context, pvzId uuid.UUID, actor model.Actor) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLastProduct", ctx, pvzId)
+	ret := m.ctrl.Call(m, "DeleteLastProduct", ctx, pvzId, actor)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteLastProduct indicates an expected call of DeleteLastProduct.
-func (mr *MockServiceMockRecorder) DeleteLastProduct(ctx, pvzId interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) DeleteLastProduct(ctx, pvzId, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastProduct", reflect.TypeOf((*MockService)(nil).DeleteLastProduct), ctx, pvzId, actor)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockService) DeleteProduct(ctx context.Context, receptionId, productId uuid.UUID, actor model.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, receptionId, productId, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockServiceMockRecorder) DeleteProduct(ctx, receptionId, productId, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockService)(nil).DeleteProduct), ctx, receptionId, productId, actor)
+}
+
+// DeleteProductType mocks base method.
+func (m *MockService) DeleteProductType(ctx context.Context, name model.ProductType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductType", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductType indicates an expected call of DeleteProductType.
+func (mr *MockServiceMockRecorder) DeleteProductType(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductType", reflect.TypeOf((*MockService)(nil).DeleteProductType), ctx, name)
+}
+
+// FindNearestPvz mocks base method.
+func (m *MockService) FindNearestPvz(ctx context.Context, query model.NearestPvzQuery) ([]model.PvzDistance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearestPvz", ctx, query)
+	ret0, _ := ret[0].([]model.PvzDistance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearestPvz indicates an expected call of FindNearestPvz.
+func (mr *MockServiceMockRecorder) FindNearestPvz(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearestPvz", reflect.TypeOf((*MockService)(nil).FindNearestPvz), ctx, query)
+}
+
+// FindProductsByBarcode mocks base method.
+func (m *MockService) FindProductsByBarcode(ctx context.Context, barcode string) ([]model.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProductsByBarcode", ctx, barcode)
+	ret0, _ := ret[0].([]model.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProductsByBarcode indicates an expected call of FindProductsByBarcode.
+func (mr *MockServiceMockRecorder) FindProductsByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProductsByBarcode", reflect.TypeOf((*MockService)(nil).FindProductsByBarcode), ctx, barcode)
+}
+
+// GetCatalogItem mocks base method.
+func (m *MockService) GetCatalogItem(ctx context.Context, code string) (*model.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogItem", ctx, code)
+	ret0, _ := ret[0].(*model.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogItem indicates an expected call of GetCatalogItem.
+func (mr *MockServiceMockRecorder) GetCatalogItem(ctx, code interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastProduct", reflect.TypeOf((*MockService)(nil).DeleteLastProduct), ctx, pvzId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogItem", reflect.TypeOf((*MockService)(nil).GetCatalogItem), ctx, code)
+}
+
+// GetPvz mocks base method.
+func (m *MockService) GetPvz(ctx context.Context, pvzId uuid.UUID) (*model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvz", ctx, pvzId)
+	ret0, _ := ret[0].(*model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvz indicates an expected call of GetPvz.
+func (mr *MockServiceMockRecorder) GetPvz(ctx, pvzId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvz", reflect.TypeOf((*MockService)(nil).GetPvz), ctx, pvzId)
 }
 
 // GetPvzInfo mocks base method.
-func (m *MockService) GetPvzInfo(ctx context.Context, startDate, endDate time.Time, page, limit int32) (*model.GetPvzInfoResponse, error) {
+func (m *MockService) GetPvzInfo(ctx context.Context, filter model.PvzInfoFilter) (*model.GetPvzInfoResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPvzInfo", ctx, startDate, endDate, page, limit)
+	ret := m.ctrl.Call(m, "GetPvzInfo", ctx, filter)
 	ret0, _ := ret[0].(*model.GetPvzInfoResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPvzInfo indicates an expected call of GetPvzInfo.
-func (mr *MockServiceMockRecorder) GetPvzInfo(ctx, startDate, endDate, page, limit interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetPvzInfo(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzInfo", reflect.TypeOf((*MockService)(nil).GetPvzInfo), ctx, filter)
+}
+
+// GetPvzList mocks base method.
+func (m *MockService) GetPvzList(ctx context.Context) ([]model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzList", ctx)
+	ret0, _ := ret[0].([]model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzList indicates an expected call of GetPvzList.
+func (mr *MockServiceMockRecorder) GetPvzList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzList", reflect.TypeOf((*MockService)(nil).GetPvzList), ctx)
+}
+
+// GetPvzPage mocks base method.
+func (m *MockService) GetPvzPage(ctx context.Context, after *model.PvzCursor, limit int32) ([]model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPvzPage", ctx, after, limit)
+	ret0, _ := ret[0].([]model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPvzPage indicates an expected call of GetPvzPage.
+func (mr *MockServiceMockRecorder) GetPvzPage(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzPage", reflect.TypeOf((*MockService)(nil).GetPvzPage), ctx, after, limit)
+}
+
+// ListCities mocks base method.
+func (m *MockService) ListCities(ctx context.Context) ([]model.CityInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCities", ctx)
+	ret0, _ := ret[0].([]model.CityInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCities indicates an expected call of ListCities.
+func (mr *MockServiceMockRecorder) ListCities(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCities", reflect.TypeOf((*MockService)(nil).ListCities), ctx)
+}
+
+// ListProductTypes mocks base method.
+func (m *MockService) ListProductTypes(ctx context.Context) ([]model.ProductTypeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductTypes", ctx)
+	ret0, _ := ret[0].([]model.ProductTypeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductTypes indicates an expected call of ListProductTypes.
+func (mr *MockServiceMockRecorder) ListProductTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductTypes", reflect.TypeOf((*MockService)(nil).ListProductTypes), ctx)
+}
+
+// RenameProductType mocks base method.
+func (m *MockService) RenameProductType(ctx context.Context, name, newName model.ProductType) (*model.ProductTypeInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameProductType", ctx, name, newName)
+	ret0, _ := ret[0].(*model.ProductTypeInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameProductType indicates an expected call of RenameProductType.
+func (mr *MockServiceMockRecorder) RenameProductType(ctx, name, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameProductType", reflect.TypeOf((*MockService)(nil).RenameProductType), ctx, name, newName)
+}
+
+// ReopenReception mocks base method.
+func (m *MockService) ReopenReception(ctx context.Context, receptionId uuid.UUID, actor model.Actor) (*model.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenReception", ctx, receptionId, actor)
+	ret0, _ := ret[0].(*model.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenReception indicates an expected call of ReopenReception.
+func (mr *MockServiceMockRecorder) ReopenReception(ctx, receptionId, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenReception", reflect.TypeOf((*MockService)(nil).ReopenReception), ctx, receptionId, actor)
+}
+
+// UpdateCity mocks base method.
+func (m *MockService) UpdateCity(ctx context.Context, name model.City, req model.UpdateCityRequest) (*model.CityInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCity", ctx, name, req)
+	ret0, _ := ret[0].(*model.CityInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCity indicates an expected call of UpdateCity.
+func (mr *MockServiceMockRecorder) UpdateCity(ctx, name, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCity", reflect.TypeOf((*MockService)(nil).UpdateCity), ctx, name, req)
+}
+
+// UpdatePvz mocks base method.
+func (m *MockService) UpdatePvz(ctx context.Context, pvzId uuid.UUID, req model.UpdatePvzRequest) (*model.Pvz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePvz", ctx, pvzId, req)
+	ret0, _ := ret[0].(*model.Pvz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePvz indicates an expected call of UpdatePvz.
+func (mr *MockServiceMockRecorder) UpdatePvz(ctx, pvzId, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPvzInfo", reflect.TypeOf((*MockService)(nil).GetPvzInfo), ctx, startDate, endDate, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePvz", reflect.TypeOf((*MockService)(nil).UpdatePvz), ctx, pvzId, req)
 }
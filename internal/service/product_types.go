@@ -0,0 +1,153 @@
+package service
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/model"
+	"context"
+	"sync"
+	"time"
+)
+
+// productTypesCacheTTL bounds how long another instance's changes to product
+// types may go unnoticed. Changes made through this instance apply at once.
+const productTypesCacheTTL = time.Minute
+
+// productTypeCache keeps the set of known product types in memory so that
+// adding products does not query product_types every time.
+type productTypeCache struct {
+	mu       sync.RWMutex
+	types    map[model.ProductType]struct{}
+	loadedAt time.Time
+	// gen is bumped by invalidate, so that a list read from the database
+	// before a change is not stored over it.
+	gen uint64
+}
+
+// get returns the cached types if they are fresh, and the generation to pass
+// to set otherwise.
+func (c *productTypeCache) get(now time.Time) (map[model.ProductType]struct{}, uint64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.types == nil || now.Sub(c.loadedAt) > productTypesCacheTTL {
+		return nil, c.gen, false
+	}
+	return c.types, c.gen, true
+}
+
+func (c *productTypeCache) generation() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gen
+}
+
+// set stores the types listed at generation gen, unless the cache was
+// invalidated since; the caller can use the returned set either way.
+func (c *productTypeCache) set(types []model.ProductTypeInfo, now time.Time, gen uint64) map[model.ProductType]struct{} {
+	set := make(map[model.ProductType]struct{}, len(types))
+	for _, t := range types {
+		set[t.Name] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen == gen {
+		c.types = set
+		c.loadedAt = now
+	}
+	return set
+}
+
+func (c *productTypeCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.types = nil
+	c.gen++
+}
+
+// productTypeExists checks the type against the cache, reloading it from the
+// database when it is empty or stale.
+func (s *Svc) productTypeExists(ctx context.Context, productType model.ProductType) (bool, error) {
+	now := time.Now()
+	types, gen, ok := s.productTypes.get(now)
+	if !ok {
+		list, err := s.repo.ListProductTypes(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to list product types", "err", err)
+			return false, err
+		}
+		types = s.productTypes.set(list, now, gen)
+	}
+
+	_, exists := types[productType]
+	return exists, nil
+}
+
+func (s *Svc) validateProductType(ctx context.Context, productType model.ProductType) error {
+	exists, err := s.productTypeExists(ctx, productType)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.ErrInvalidProductType
+	}
+	return nil
+}
+
+func (s *Svc) ListProductTypes(ctx context.Context) ([]model.ProductTypeInfo, error) {
+	gen := s.productTypes.generation()
+	types, err := s.repo.ListProductTypes(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to list product types", "err", err)
+		return nil, err
+	}
+	s.productTypes.set(types, time.Now(), gen)
+	return types, nil
+}
+
+func (s *Svc) CreateProductType(ctx context.Context, name model.ProductType) (*model.ProductTypeInfo, error) {
+	if !name.IsWellFormed() {
+		return nil, errors.ErrInvalidProductType
+	}
+
+	res, err := s.repo.CreateProductType(ctx, name)
+	if err != nil {
+		if err != errors.ErrProductTypeAlreadyExists {
+			logger.FromContext(ctx).Error("failed to create product type", "err", err)
+		}
+		return nil, err
+	}
+
+	s.productTypes.invalidate()
+	return res, nil
+}
+
+func (s *Svc) RenameProductType(ctx context.Context, name, newName model.ProductType) (*model.ProductTypeInfo, error) {
+	if !newName.IsWellFormed() {
+		return nil, errors.ErrInvalidProductType
+	}
+
+	res, err := s.repo.RenameProductType(ctx, name, newName)
+	if err != nil {
+		if err != errors.ErrProductTypeDoesNotExist && err != errors.ErrProductTypeAlreadyExists {
+			logger.FromContext(ctx).Error("failed to rename product type", "err", err)
+		}
+		return nil, err
+	}
+
+	s.productTypes.invalidate()
+	return res, nil
+}
+
+func (s *Svc) DeleteProductType(ctx context.Context, name model.ProductType) error {
+	err := s.repo.DeleteProductType(ctx, name)
+	if err != nil {
+		if err != errors.ErrProductTypeDoesNotExist && err != errors.ErrProductTypeInUse {
+			logger.FromContext(ctx).Error("failed to delete product type", "err", err)
+		}
+		return err
+	}
+
+	s.productTypes.invalidate()
+	return nil
+}
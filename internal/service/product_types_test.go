@@ -0,0 +1,203 @@
+package service
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProductTypeCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	books := model.ProductType("книги")
+
+	t.Run("loads once while fresh", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().ListProductTypes(gomock.Any()).Return([]model.ProductTypeInfo{{Name: model.ProductTypeShoes}}, nil).Times(1)
+
+		for range 3 {
+			exists, err := s.svc.productTypeExists(ctx, model.ProductTypeShoes)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
+	t.Run("reloads when stale", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().ListProductTypes(gomock.Any()).Return([]model.ProductTypeInfo{{Name: books}}, nil)
+		s.svc.productTypes.set(nil, time.Now().Add(-productTypesCacheTTL-time.Second), 0)
+
+		exists, err := s.svc.productTypeExists(ctx, books)
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+	t.Run("create invalidates", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		gomock.InOrder(
+			s.mockRepo.EXPECT().ListProductTypes(gomock.Any()).Return([]model.ProductTypeInfo{{Name: model.ProductTypeShoes}}, nil),
+			s.mockRepo.EXPECT().CreateProductType(gomock.Any(), books).Return(&model.ProductTypeInfo{Name: books}, nil),
+			s.mockRepo.EXPECT().ListProductTypes(gomock.Any()).Return([]model.ProductTypeInfo{{Name: model.ProductTypeShoes}, {Name: books}}, nil),
+		)
+
+		exists, err := s.svc.productTypeExists(ctx, books)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		_, err = s.svc.CreateProductType(ctx, books)
+		require.NoError(t, err)
+
+		exists, err = s.svc.productTypeExists(ctx, books)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func Test_ProductTypeCacheGeneration(t *testing.T) {
+	t.Parallel()
+
+	var c productTypeCache
+	now := time.Now()
+	_, gen, ok := c.get(now)
+	require.False(t, ok)
+
+	// a change lands while the list is read from the database
+	c.invalidate()
+	loaded := c.set([]model.ProductTypeInfo{{Name: model.ProductTypeShoes}}, now, gen)
+
+	assert.Contains(t, loaded, model.ProductTypeShoes)
+	_, _, ok = c.get(now)
+	assert.False(t, ok, "a list loaded before invalidate must not be cached")
+
+	_, gen, _ = c.get(now)
+	c.set([]model.ProductTypeInfo{{Name: model.ProductTypeShoes}}, now, gen)
+	types, _, ok := c.get(now)
+	require.True(t, ok)
+	assert.Contains(t, types, model.ProductTypeShoes)
+}
+
+func Test_CreateProductType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("malformed name", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		_, err := s.svc.CreateProductType(ctx, " ")
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+	})
+	t.Run("already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().CreateProductType(gomock.Any(), model.ProductTypeShoes).Return(nil, customErrors.ErrProductTypeAlreadyExists)
+
+		_, err := s.svc.CreateProductType(ctx, model.ProductTypeShoes)
+
+		require.ErrorIs(t, err, customErrors.ErrProductTypeAlreadyExists)
+	})
+}
+
+func Test_RenameProductType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().RenameProductType(gomock.Any(), model.ProductTypeShoes, model.ProductType("обувь и аксессуары")).
+			Return(&model.ProductTypeInfo{Name: "обувь и аксессуары"}, nil)
+
+		res, err := s.svc.RenameProductType(ctx, model.ProductTypeShoes, "обувь и аксессуары")
+
+		require.NoError(t, err)
+		assert.Equal(t, model.ProductType("обувь и аксессуары"), res.Name)
+	})
+	t.Run("malformed new name", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		_, err := s.svc.RenameProductType(ctx, model.ProductTypeShoes, "")
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().RenameProductType(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrProductTypeDoesNotExist)
+
+		_, err := s.svc.RenameProductType(ctx, "книги", "журналы")
+
+		require.ErrorIs(t, err, customErrors.ErrProductTypeDoesNotExist)
+	})
+}
+
+func Test_DeleteProductType(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().DeleteProductType(gomock.Any(), model.ProductType("книги")).Return(nil)
+
+		err := s.svc.DeleteProductType(ctx, "книги")
+
+		require.NoError(t, err)
+	})
+	t.Run("in use", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().DeleteProductType(gomock.Any(), gomock.Any()).Return(customErrors.ErrProductTypeInUse)
+
+		err := s.svc.DeleteProductType(ctx, model.ProductTypeShoes)
+
+		require.ErrorIs(t, err, customErrors.ErrProductTypeInUse)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockRepo.EXPECT().DeleteProductType(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		err := s.svc.DeleteProductType(ctx, model.ProductTypeShoes)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
@@ -3,256 +3,722 @@ package service
 
 import (
 	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/metrics"
 	"avito2/internal/model"
 	"avito2/internal/repository"
 	"context"
-	"log"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
 )
 
 type Service interface {
-	CreatePvz(ctx context.Context, city model.City) (*model.Pvz, error)
-	CloseLastReception(ctx context.Context, pvzId uuid.UUID) (*model.Reception, error)
-	DeleteLastProduct(ctx context.Context, pvzId uuid.UUID) error
-	CreateReception(ctx context.Context, pvzId uuid.UUID) (*model.Reception, error)
-	AddProduct(ctx context.Context, pvzId uuid.UUID, productType model.ProductType) (*model.Product, error)
-	GetPvzInfo(ctx context.Context, startDate, endDate time.Time, page, limit int32) (*model.GetPvzInfoResponse, error)
+	CreatePvz(ctx context.Context, req model.CreatePvzRequest) (*model.Pvz, error)
+	GetPvz(ctx context.Context, pvzId uuid.UUID) (*model.Pvz, error)
+	UpdatePvz(ctx context.Context, pvzId uuid.UUID, req model.UpdatePvzRequest) (*model.Pvz, error)
+	FindNearestPvz(ctx context.Context, query model.NearestPvzQuery) ([]model.PvzDistance, error)
+	GetPvzList(ctx context.Context) ([]model.Pvz, error)
+	GetPvzPage(ctx context.Context, after *model.PvzCursor, limit int32) ([]model.Pvz, error)
+	CloseLastReception(ctx context.Context, pvzId uuid.UUID, actor model.Actor) (*model.Reception, error)
+	CancelReception(ctx context.Context, receptionId uuid.UUID, actor model.Actor) (*model.Reception, error)
+	ReopenReception(ctx context.Context, receptionId uuid.UUID, actor model.Actor) (*model.Reception, error)
+	DeleteLastProduct(ctx context.Context, pvzId uuid.UUID, actor model.Actor) error
+	DeleteProduct(ctx context.Context, receptionId, productId uuid.UUID, actor model.Actor) error
+	CreateReception(ctx context.Context, pvzId uuid.UUID, actor model.Actor) (*model.Reception, error)
+	AddProduct(ctx context.Context, pvzId uuid.UUID, productType model.ProductType, barcode string) (*model.Product, error)
+	AddProducts(ctx context.Context, pvzId uuid.UUID, items []model.ProductBatchItem) ([]model.Product, error)
+	GetPvzInfo(ctx context.Context, filter model.PvzInfoFilter) (*model.GetPvzInfoResponse, error)
+	CreateCatalogItem(ctx context.Context, item model.CatalogItem) (*model.CatalogItem, error)
+	GetCatalogItem(ctx context.Context, code string) (*model.CatalogItem, error)
+	FindProductsByBarcode(ctx context.Context, barcode string) ([]model.ProductLocation, error)
+	ListProductTypes(ctx context.Context) ([]model.ProductTypeInfo, error)
+	CreateProductType(ctx context.Context, name model.ProductType) (*model.ProductTypeInfo, error)
+	RenameProductType(ctx context.Context, name, newName model.ProductType) (*model.ProductTypeInfo, error)
+	DeleteProductType(ctx context.Context, name model.ProductType) error
+	ListCities(ctx context.Context) ([]model.CityInfo, error)
+	CreateCity(ctx context.Context, city model.CityInfo) (*model.CityInfo, error)
+	UpdateCity(ctx context.Context, name model.City, req model.UpdateCityRequest) (*model.CityInfo, error)
+	DeleteCity(ctx context.Context, name model.City) error
 }
 
 type Svc struct {
-	repo repository.Repository
+	repo         repository.Repository
+	productTypes *productTypeCache
 }
 
 func NewService(repo repository.Repository) *Svc {
 	return &Svc{
-		repo: repo,
+		repo:         repo,
+		productTypes: &productTypeCache{},
 	}
 }
 
-func (s *Svc) CreatePvz(ctx context.Context, city model.City) (*model.Pvz, error) {
-	pvz, err := s.repo.CreatePvz(ctx, city)
+// checkCity makes sure a pvz may be opened in the city. Unknown cities are
+// rejected as invalid and deactivated ones as inactive.
+func (s *Svc) checkCity(ctx context.Context, city model.City) error {
+	cityInfo, err := s.repo.GetCity(ctx, city)
 	if err != nil {
-		log.Println("failed to create pvz with err:", err)
+		if err == errors.ErrCityDoesNotExist {
+			return errors.ErrInvalidCity
+		}
+		logger.FromContext(ctx).Error("failed to get city", "err", err)
+		return err
+	}
+	if !cityInfo.Active {
+		return errors.ErrCityInactive
+	}
+	return nil
+}
+
+func (s *Svc) CreatePvz(ctx context.Context, req model.CreatePvzRequest) (*model.Pvz, error) {
+	if field := req.InvalidField(); field != "" {
+		return nil, invalidPvzField(field)
+	}
+	if err := s.checkCity(ctx, req.City); err != nil {
 		return nil, err
 	}
+
+	pvz, err := s.repo.CreatePvz(ctx, req)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create pvz", "err", err)
+		return nil, err
+	}
+
+	metrics.PvzCreatedTotal.WithLabelValues(string(pvz.City)).Inc()
 	return pvz, nil
 }
 
-func (s *Svc) CloseLastReception(ctx context.Context, pvzId uuid.UUID) (*model.Reception, error) {
-	tx, err := s.repo.BeginTransaction(ctx, &pgx.TxOptions{
-		IsoLevel: pgx.ReadCommitted,
+func (s *Svc) GetPvz(ctx context.Context, pvzId uuid.UUID) (*model.Pvz, error) {
+	var pvz *model.Pvz
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadOnly,
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvz, err = s.repo.GetPvz(ctx, tx, pvzId)
+		if err != nil && err != errors.ErrPvzDoesNotExist {
+			logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+		}
+		return err
 	})
-
 	if err != nil {
-		log.Println("failed to begin tx with err:", err)
 		return nil, err
 	}
+	return pvz, nil
+}
+
+// UpdatePvz moves the pvz to another city of the registry or deactivates and
+// reactivates it. Receptions already in progress at a deactivated pvz can
+// still be closed or cancelled.
+func (s *Svc) UpdatePvz(ctx context.Context, pvzId uuid.UUID, req model.UpdatePvzRequest) (*model.Pvz, error) {
+	if field := req.InvalidField(); field != "" {
+		return nil, invalidPvzField(field)
+	}
+	if req.City != nil {
+		if err := s.checkCity(ctx, *req.City); err != nil {
+			return nil, err
+		}
+	}
 
-	_, err = s.repo.GetPvz(ctx, tx, pvzId)
+	pvz, err := s.repo.UpdatePvz(ctx, pvzId, req)
 	if err != nil {
 		if err != errors.ErrPvzDoesNotExist {
-			log.Println("failed to get pvz with err:", err)
+			logger.FromContext(ctx).Error("failed to update pvz", "err", err)
 		}
-		s.repo.RollbackTx(ctx, tx)
 		return nil, err
 	}
+	return pvz, nil
+}
 
-	reception, err := s.repo.UpdateLastReceptionStatus(ctx, tx, pvzId)
+// FindNearestPvz returns active pvz around the point, the nearest first.
+func (s *Svc) FindNearestPvz(ctx context.Context, query model.NearestPvzQuery) ([]model.PvzDistance, error) {
+	res, err := s.repo.FindNearestPvz(ctx, query)
 	if err != nil {
-		log.Println("failed to close last reception with err:", err)
-		s.repo.RollbackTx(ctx, tx)
+		logger.FromContext(ctx).Error("failed to find nearest pvz", "err", err)
 		return nil, err
 	}
-	s.repo.CommitTx(ctx, tx)
-
-	if reception == nil {
-		return nil, errors.ErrReceptionInProgressDoesNotExist
-	}
-	return reception, nil
+	return res, nil
 }
 
-func (s *Svc) DeleteLastProduct(ctx context.Context, pvzId uuid.UUID) error {
-	tx, err := s.repo.BeginTransaction(ctx, &pgx.TxOptions{
-		IsoLevel: pgx.ReadCommitted,
+func invalidPvzField(field string) error {
+	return errors.WithDetails(errors.ErrInvalidPvz, map[string]any{
+		"field": field,
 	})
+}
 
+func (s *Svc) GetPvzList(ctx context.Context) ([]model.Pvz, error) {
+	pvzList, err := s.repo.GetPvzList(ctx)
 	if err != nil {
-		log.Println("failed to begin tx with err:", err)
-		return err
+		logger.FromContext(ctx).Error("failed to get pvz list", "err", err)
+		return nil, err
 	}
+	return pvzList, nil
+}
 
-	_, err = s.repo.GetPvz(ctx, tx, pvzId)
+// GetPvzPage returns the pvz list one page at a time, see
+// repository.Repo.GetPvzPage.
+func (s *Svc) GetPvzPage(ctx context.Context, after *model.PvzCursor, limit int32) ([]model.Pvz, error) {
+	pvzList, err := s.repo.GetPvzPage(ctx, after, limit)
 	if err != nil {
-		if err != errors.ErrPvzDoesNotExist {
-			log.Println("failed to get pvz with err:", err)
+		logger.FromContext(ctx).Error("failed to get pvz page", "err", err)
+		return nil, err
+	}
+	return pvzList, nil
+}
+
+func (s *Svc) CloseLastReception(ctx context.Context, pvzId uuid.UUID, actor model.Actor) (*model.Reception, error) {
+	var pvz *model.Pvz
+	var reception *model.Reception
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvz, err = s.repo.GetPvz(ctx, tx, pvzId)
+		if err != nil {
+			if err != errors.ErrPvzDoesNotExist {
+				logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			}
+			return err
 		}
-		s.repo.RollbackTx(ctx, tx)
+
+		curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get current reception", "err", err)
+			return err
+		}
+
+		if curReception == nil {
+			return errors.ErrReceptionInProgressDoesNotExist
+		}
+
+		reception, err = s.transitionReception(ctx, tx, curReception, model.ReceptionStatusClose, actor)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
-	if err != nil {
-		log.Println("failed to get current reception with err:", err)
-		s.repo.RollbackTx(ctx, tx)
-		return err
+	metrics.ReceptionsClosedTotal.WithLabelValues(string(pvz.City)).Inc()
+	return reception, nil
+}
+
+// CancelReception cancels a reception in progress. Only employees may do it.
+func (s *Svc) CancelReception(ctx context.Context, receptionId uuid.UUID, actor model.Actor) (*model.Reception, error) {
+	if actor.Role != model.RoleEmployee {
+		return nil, errors.ErrAccessDenied
 	}
 
-	if curReception != nil {
-		err = s.repo.DeleteLastProduct(ctx, tx, curReception.Id)
+	var pvz *model.Pvz
+	var reception *model.Reception
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	}, func(tx pgx.Tx) error {
+		curReception, err := s.getReception(ctx, tx, receptionId)
+		if err != nil {
+			return err
+		}
+
+		pvz, err = s.repo.GetPvz(ctx, tx, curReception.PvzId)
 		if err != nil {
-			if err != errors.ErrNoProductToDelete {
-				log.Println("failed to delete last product from current reception with err:", err)
-				s.repo.RollbackTx(ctx, tx)
-				return err
-			}
-			s.repo.CommitTx(ctx, tx)
+			logger.FromContext(ctx).Error("failed to get pvz", "err", err)
 			return err
 		}
-		s.repo.CommitTx(ctx, tx)
-		return nil
+
+		reception, err = s.transitionReception(ctx, tx, curReception, model.ReceptionStatusCancelled, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	s.repo.CommitTx(ctx, tx)
-	return errors.ErrReceptionInProgressDoesNotExist
+	metrics.ReceptionsCancelledTotal.WithLabelValues(string(pvz.City)).Inc()
+	return reception, nil
 }
 
-func (s *Svc) CreateReception(ctx context.Context, pvzId uuid.UUID) (*model.Reception, error) {
-	tx, err := s.repo.BeginTransaction(ctx, &pgx.TxOptions{
-		IsoLevel: pgx.Serializable,
-	})
+// ReopenReception moves a closed reception back in progress. Only moderators
+// may do it, only within model.ReceptionReopenWindow after it was closed and
+// only at an active pvz.
+func (s *Svc) ReopenReception(ctx context.Context, receptionId uuid.UUID, actor model.Actor) (*model.Reception, error) {
+	if actor.Role != model.RoleModerator {
+		return nil, errors.ErrAccessDenied
+	}
 
+	var pvz *model.Pvz
+	var reception *model.Reception
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	}, func(tx pgx.Tx) error {
+		curReception, err := s.getReception(ctx, tx, receptionId)
+		if err != nil {
+			return err
+		}
+
+		if !curReception.Status.CanTransitionTo(model.ReceptionStatusInProgress) {
+			return invalidTransition(curReception.Status, model.ReceptionStatusInProgress)
+		}
+
+		closedWithin, err := s.repo.ReceptionClosedWithin(ctx, tx, receptionId, model.ReceptionReopenWindow)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to check reception close time", "err", err)
+			return err
+		}
+		if !closedWithin {
+			return errors.ErrReceptionReopenWindowExpired
+		}
+
+		pvz, err = s.repo.GetPvz(ctx, tx, curReception.PvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			return err
+		}
+		if !pvz.Active {
+			return errors.ErrPvzInactive
+		}
+
+		reception, err = s.transitionReception(ctx, tx, curReception, model.ReceptionStatusInProgress, actor)
+		return err
+	})
 	if err != nil {
-		log.Println("failed to begin tx with err:", err)
 		return nil, err
 	}
 
-	_, err = s.repo.GetPvz(ctx, tx, pvzId)
+	metrics.ReceptionsReopenedTotal.WithLabelValues(string(pvz.City)).Inc()
+	return reception, nil
+}
+
+func (s *Svc) getReception(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID) (*model.Reception, error) {
+	reception, err := s.repo.GetReception(ctx, tx, receptionId)
 	if err != nil {
-		if err != errors.ErrPvzDoesNotExist {
-			log.Println("failed to get pvz with err:", err)
+		if err != errors.ErrReceptionDoesNotExist {
+			logger.FromContext(ctx).Error("failed to get reception", "err", err)
 		}
-		s.repo.RollbackTx(ctx, tx)
 		return nil, err
 	}
+	return reception, nil
+}
+
+// transitionReception validates the status change against the reception state
+// machine, applies it and records who made it.
+func (s *Svc) transitionReception(ctx context.Context, tx pgx.Tx, reception *model.Reception, to model.ReceptionStatus, actor model.Actor) (*model.Reception, error) {
+	if !reception.Status.CanTransitionTo(to) {
+		return nil, invalidTransition(reception.Status, to)
+	}
 
-	curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+	updated, err := s.repo.UpdateReceptionStatus(ctx, tx, reception.Id, to, actor.Id)
 	if err != nil {
-		log.Println("failed to get current reception with err:", err)
-		s.repo.RollbackTx(ctx, tx)
+		if err != errors.ErrReceptionInProgressAlreadyExists {
+			logger.FromContext(ctx).Error("failed to update reception status", "err", err)
+		}
 		return nil, err
 	}
 
-	if curReception == nil {
-		reception, err := s.repo.CreateReception(ctx, tx, pvzId)
+	if err := s.recordTransition(ctx, tx, reception.Id, reception.Status, to, actor); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Svc) recordTransition(ctx context.Context, tx pgx.Tx, receptionId uuid.UUID, from, to model.ReceptionStatus, actor model.Actor) error {
+	_, err := s.repo.CreateReceptionTransition(ctx, tx, model.ReceptionTransition{
+		ReceptionId: receptionId,
+		FromStatus:  from,
+		ToStatus:    to,
+		ActorId:     actor.Id,
+		ActorRole:   actor.Role,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to record reception transition", "err", err)
+	}
+	return err
+}
+
+func invalidTransition(from, to model.ReceptionStatus) error {
+	return errors.WithDetails(errors.ErrInvalidReceptionTransition, map[string]any{
+		"from": from,
+		"to":   to,
+	})
+}
+
+func (s *Svc) DeleteLastProduct(ctx context.Context, pvzId uuid.UUID, actor model.Actor) error {
+	var pvz *model.Pvz
+	var product *model.Product
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvz, err = s.repo.GetPvz(ctx, tx, pvzId)
 		if err != nil {
-			log.Println("failed to create reception with err:", err)
-			s.repo.RollbackTx(ctx, tx)
-			return nil, err
+			if err != errors.ErrPvzDoesNotExist {
+				logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			}
+			return err
+		}
+
+		curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get current reception", "err", err)
+			return err
+		}
+
+		if curReception == nil {
+			return errors.ErrReceptionInProgressDoesNotExist
+		}
+
+		product, err = s.repo.DeleteLastProduct(ctx, tx, curReception.Id)
+		if err != nil {
+			if err != errors.ErrNoProductToDelete {
+				logger.FromContext(ctx).Error("failed to delete last product from current reception", "err", err)
+			}
+			return err
 		}
-		s.repo.CommitTx(ctx, tx)
-		return reception, nil
+		return s.recordProductDeletion(ctx, tx, product, curReception.Id, actor)
+	})
+	if err != nil {
+		return err
 	}
 
-	s.repo.CommitTx(ctx, tx)
-	return nil, errors.ErrReceptionInProgressAlreadyExists
+	metrics.ProductsDeletedTotal.WithLabelValues(string(pvz.City), string(product.Type)).Inc()
+	return nil
 }
 
-func (s *Svc) AddProduct(ctx context.Context, pvzId uuid.UUID, productType model.ProductType) (*model.Product, error) {
-	tx, err := s.repo.BeginTransaction(ctx, &pgx.TxOptions{
+// DeleteProduct removes any product of a reception that is still in progress,
+// not only the last scanned one.
+func (s *Svc) DeleteProduct(ctx context.Context, receptionId, productId uuid.UUID, actor model.Actor) error {
+	var pvz *model.Pvz
+	var product *model.Product
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
 		IsoLevel: pgx.ReadCommitted,
-	})
+	}, func(tx pgx.Tx) error {
+		reception, err := s.getReception(ctx, tx, receptionId)
+		if err != nil {
+			return err
+		}
+
+		if reception.Status != model.ReceptionStatusInProgress {
+			return errors.ErrReceptionNotInProgress
+		}
 
+		pvz, err = s.repo.GetPvz(ctx, tx, reception.PvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			return err
+		}
+
+		product, err = s.repo.DeleteProduct(ctx, tx, receptionId, productId)
+		if err != nil {
+			if err != errors.ErrProductDoesNotExist {
+				logger.FromContext(ctx).Error("failed to delete product", "err", err)
+			}
+			return err
+		}
+		return s.recordProductDeletion(ctx, tx, product, receptionId, actor)
+	})
 	if err != nil {
-		log.Println("failed to begin tx with err:", err)
-		return nil, err
+		return err
 	}
 
-	_, err = s.repo.GetPvz(ctx, tx, pvzId)
+	metrics.ProductsDeletedTotal.WithLabelValues(string(pvz.City), string(product.Type)).Inc()
+	return nil
+}
+
+func (s *Svc) recordProductDeletion(ctx context.Context, tx pgx.Tx, product *model.Product, receptionId uuid.UUID, actor model.Actor) error {
+	err := s.repo.CreateProductDeletion(ctx, tx, model.ProductDeletion{
+		ProductId:       product.Id,
+		ProductType:     product.Type,
+		ProductDateTime: product.DateTime,
+		ReceptionId:     receptionId,
+		ActorId:         actor.Id,
+		ActorRole:       actor.Role,
+	})
 	if err != nil {
-		if err != errors.ErrPvzDoesNotExist {
-			log.Println("failed to get pvz with err:", err)
-		}
-		s.repo.RollbackTx(ctx, tx)
-		return nil, err
+		logger.FromContext(ctx).Error("failed to record product deletion", "err", err)
 	}
+	return err
+}
+
+func (s *Svc) CreateReception(ctx context.Context, pvzId uuid.UUID, actor model.Actor) (*model.Reception, error) {
+	var pvz *model.Pvz
+	var reception *model.Reception
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel: pgx.Serializable,
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvz, err = s.repo.GetPvz(ctx, tx, pvzId)
+		if err != nil {
+			if err != errors.ErrPvzDoesNotExist {
+				logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			}
+			return err
+		}
+		if !pvz.Active {
+			return errors.ErrPvzInactive
+		}
 
-	curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+		curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get current reception", "err", err)
+			return err
+		}
+
+		if curReception != nil {
+			return errors.ErrReceptionInProgressAlreadyExists
+		}
+
+		reception, err = s.repo.CreateReception(ctx, tx, pvzId)
+		if err != nil {
+			if err != errors.ErrReceptionInProgressAlreadyExists {
+				logger.FromContext(ctx).Error("failed to create reception", "err", err)
+			}
+			return err
+		}
+
+		return s.recordTransition(ctx, tx, reception.Id, "", model.ReceptionStatusInProgress, actor)
+	})
 	if err != nil {
-		log.Println("failed to get current reception with err:", err)
-		s.repo.RollbackTx(ctx, tx)
 		return nil, err
 	}
 
-	if curReception != nil {
-		product, err := s.repo.AddProduct(ctx, tx, curReception.Id, productType)
-		if err != nil {
-			log.Println("failed to add product to current reception with err:", err)
-			s.repo.RollbackTx(ctx, tx)
+	metrics.ReceptionsCreatedTotal.WithLabelValues(string(pvz.City)).Inc()
+	return reception, nil
+}
+
+// AddProduct adds a product to the current reception of the pvz. With a
+// barcode the product type is taken from the catalog item.
+func (s *Svc) AddProduct(ctx context.Context, pvzId uuid.UUID, productType model.ProductType, barcode string) (*model.Product, error) {
+	if barcode == "" || productType != "" {
+		if err := s.validateProductType(ctx, productType); err != nil {
 			return nil, err
 		}
-		s.repo.CommitTx(ctx, tx)
-		return product, nil
 	}
 
-	s.repo.CommitTx(ctx, tx)
-	return nil, errors.ErrReceptionInProgressDoesNotExist
-}
-
-func (s *Svc) GetPvzInfo(ctx context.Context, startDate, endDate time.Time, page, limit int32) (*model.GetPvzInfoResponse, error) {
-	tx, err := s.repo.BeginTransaction(ctx, &pgx.TxOptions{
+	var pvz *model.Pvz
+	var product *model.Product
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
 		IsoLevel: pgx.ReadCommitted,
-	})
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvz, err = s.repo.GetPvz(ctx, tx, pvzId)
+		if err != nil {
+			if err != errors.ErrPvzDoesNotExist {
+				logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			}
+			return err
+		}
+		if !pvz.Active {
+			return errors.ErrPvzInactive
+		}
+
+		curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get current reception", "err", err)
+			return err
+		}
+
+		if curReception == nil {
+			return errors.ErrReceptionInProgressDoesNotExist
+		}
+
+		if barcode != "" {
+			productType, err = s.catalogProductType(ctx, tx, barcode, productType)
+			if err != nil {
+				return err
+			}
+		}
 
+		product, err = s.repo.AddProduct(ctx, tx, curReception.Id, productType, barcode)
+		if err != nil {
+			return s.addProductsError(ctx, err)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Println("failed to begin tx with err:", err)
 		return nil, err
 	}
 
-	offset := (page - 1) * limit
+	metrics.ProductsAddedTotal.WithLabelValues(string(pvz.City), string(product.Type)).Inc()
+	return product, nil
+}
 
-	receptions, err := s.repo.GetReceptionsForPeriod(ctx, tx, startDate, endDate, offset, limit)
+// AddProducts adds all items to the current reception of the pvz in one
+// transaction, so either every item is accepted or none is. Invalid items are
+// reported together in the details of ErrInvalidProductBatch.
+func (s *Svc) AddProducts(ctx context.Context, pvzId uuid.UUID, items []model.ProductBatchItem) ([]model.Product, error) {
+	itemErrors, err := s.validateBatchItems(ctx, items)
 	if err != nil {
-		log.Println("failed to get receptions for period with err:", err)
-		s.repo.RollbackTx(ctx, tx)
 		return nil, err
 	}
+	if len(itemErrors) > 0 {
+		return nil, errors.WithDetails(errors.ErrInvalidProductBatch, map[string]any{
+			"items": itemErrors,
+		})
+	}
 
-	pvzMap := make(map[uuid.UUID]*model.PvzInfo, limit)
-	for _, reception := range receptions {
-		products, err := s.repo.GetProductsInReception(ctx, tx, reception.Id)
+	var pvz *model.Pvz
+	var products []model.Product
+	err = s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvz, err = s.repo.GetPvz(ctx, tx, pvzId)
 		if err != nil {
-			log.Println("failed to get products with err:", err)
-			s.repo.RollbackTx(ctx, tx)
-			return nil, err
+			if err != errors.ErrPvzDoesNotExist {
+				logger.FromContext(ctx).Error("failed to get pvz", "err", err)
+			}
+			return err
+		}
+		if !pvz.Active {
+			return errors.ErrPvzInactive
+		}
+
+		curReception, err := s.repo.GetCurrentReception(ctx, tx, pvzId)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get current reception", "err", err)
+			return err
 		}
 
-		pvzInfo, ok := pvzMap[reception.PvzId]
-		if !ok {
-			pvz, err := s.repo.GetPvz(ctx, tx, reception.PvzId)
+		if curReception == nil {
+			return errors.ErrReceptionInProgressDoesNotExist
+		}
+
+		resolved, itemErrors, err := s.resolveBatchCatalog(ctx, tx, items)
+		if err != nil {
+			return err
+		}
+		if len(itemErrors) > 0 {
+			return errors.WithDetails(errors.ErrInvalidProductBatch, map[string]any{
+				"items": itemErrors,
+			})
+		}
+
+		products, err = s.repo.AddProducts(ctx, tx, curReception.Id, resolved)
+		if err != nil {
+			return s.addProductsError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range products {
+		metrics.ProductsAddedTotal.WithLabelValues(string(pvz.City), string(product.Type)).Inc()
+	}
+	return products, nil
+}
+
+// addProductsError handles a failed product insert. A type that passed
+// validation but was deleted meanwhile means the cached types are outdated.
+func (s *Svc) addProductsError(ctx context.Context, err error) error {
+	if err == errors.ErrInvalidProductType {
+		s.productTypes.invalidate()
+		return err
+	}
+	logger.FromContext(ctx).Error("failed to add products to current reception", "err", err)
+	return err
+}
+
+// validateBatchItems checks every item instead of stopping at the first bad
+// one, so the client can fix the whole batch in one go.
+func (s *Svc) validateBatchItems(ctx context.Context, items []model.ProductBatchItem) ([]model.BatchItemError, error) {
+	var res []model.BatchItemError
+	for i, item := range items {
+		// an item with a barcode can leave its type to the catalog
+		var err error
+		exists := item.Barcode != "" && item.Type == ""
+		if !exists {
+			exists, err = s.productTypeExists(ctx, item.Type)
 			if err != nil {
-				log.Println("failed to get pvz with err:", err)
-				s.repo.RollbackTx(ctx, tx)
 				return nil, err
 			}
+		}
 
-			pvzInfo = &model.PvzInfo{
-				Pvz:        *pvz,
-				Receptions: []model.ReceptionInfo{},
-			}
+		switch {
+		case !exists:
+			err = errors.ErrInvalidProductType
+		case item.Barcode != "" && !model.IsValidBarcode(item.Barcode):
+			err = errors.ErrInvalidBarcode
+		default:
+			continue
+		}
 
-			pvzMap[reception.PvzId] = pvzInfo
+		code, _, sentinel := errors.Lookup(err)
+		res = append(res, model.BatchItemError{Index: i, Code: code, Message: sentinel.Error()})
+	}
+	return res, nil
+}
+
+// resolveBatchCatalog looks up every barcode of the batch in one query. As in
+// AddProduct, an item with a barcode takes its type from the catalog, and a
+// type given by the client must agree with it.
+func (s *Svc) resolveBatchCatalog(ctx context.Context, tx pgx.Tx, items []model.ProductBatchItem) ([]model.ProductBatchItem, []model.BatchItemError, error) {
+	var barcodes []string
+	for _, item := range items {
+		if item.Barcode != "" {
+			barcodes = append(barcodes, item.Barcode)
 		}
-		pvzInfo.Receptions = append(pvzInfo.Receptions, model.ReceptionInfo{Reception: reception, Products: products})
 	}
-	s.repo.CommitTx(ctx, tx)
+	if len(barcodes) == 0 {
+		return items, nil, nil
+	}
 
-	res := &model.GetPvzInfoResponse{}
-	for _, v := range pvzMap {
-		res.PvzList = append(res.PvzList, *v)
+	catalogItems, err := s.repo.GetCatalogItemsByBarcodes(ctx, tx, barcodes)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get catalog items", "err", err)
+		return nil, nil, err
+	}
+	catalogTypes := make(map[string]model.ProductType, len(catalogItems))
+	for _, item := range catalogItems {
+		catalogTypes[item.Barcode] = item.Type
 	}
 
+	resolved := make([]model.ProductBatchItem, len(items))
+	var itemErrors []model.BatchItemError
+	for i, item := range items {
+		resolved[i] = item
+		if item.Barcode == "" {
+			continue
+		}
+
+		catalogType, ok := catalogTypes[item.Barcode]
+		switch {
+		case !ok:
+			err = errors.ErrCatalogItemDoesNotExist
+		case item.Type != "" && item.Type != catalogType:
+			err = errors.ErrInvalidProductType
+		default:
+			resolved[i].Type = catalogType
+			continue
+		}
+
+		code, _, sentinel := errors.Lookup(err)
+		itemErrors = append(itemErrors, model.BatchItemError{Index: i, Code: code, Message: sentinel.Error()})
+	}
+	return resolved, itemErrors, nil
+}
+
+func (s *Svc) GetPvzInfo(ctx context.Context, filter model.PvzInfoFilter) (*model.GetPvzInfoResponse, error) {
+	var pvzList []model.PvzInfo
+	err := s.repo.WithTx(ctx, &pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}, func(tx pgx.Tx) error {
+		var err error
+		pvzList, err = s.repo.GetPvzInfoForPeriod(ctx, tx, filter)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get pvz info for period", "err", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.GetPvzInfoResponse{PvzList: pvzList}
+	if len(pvzList) > 0 && len(pvzList) == int(filter.Limit) {
+		last := pvzList[len(pvzList)-1].Pvz
+		res.NextCursor = model.PvzCursor{RegistrationDate: last.RegistrationDate, Id: last.Id}.Encode()
+	}
 	return res, nil
 }
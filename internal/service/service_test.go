@@ -2,6 +2,7 @@ package service
 
 import (
 	customErrors "avito2/internal/errors"
+	"avito2/internal/metrics"
 	"avito2/internal/model"
 	"context"
 	"errors"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +23,7 @@ func Test_CreatePvz(t *testing.T) {
 	var (
 		ctx         = context.Background()
 		city        = model.CityMoscow
+		cityInfo    = &model.CityInfo{Name: city, Active: true}
 		expectedPvz = &model.Pvz{City: city}
 		dbErr       = errors.New("failed to create pvz")
 	)
@@ -29,105 +33,613 @@ func Test_CreatePvz(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), city).Return(cityInfo, nil)
 		s.mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(expectedPvz, nil)
 
-		pvz, err := s.svc.CreatePvz(ctx, city)
+		pvz, err := s.svc.CreatePvz(ctx, model.CreatePvzRequest{City: city})
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedPvz, pvz)
 	})
 
+	t.Run("increments pvz created counter", func(t *testing.T) {
+		t.Parallel()
+
+		var metricsCity model.City = "metrics-test-city"
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), metricsCity).Return(&model.CityInfo{Name: metricsCity, Active: true}, nil)
+		s.mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(&model.Pvz{City: metricsCity}, nil)
+		before := testutil.ToFloat64(metrics.PvzCreatedTotal.WithLabelValues(string(metricsCity)))
+
+		_, err := s.svc.CreatePvz(ctx, model.CreatePvzRequest{City: metricsCity})
+
+		require.NoError(t, err)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.PvzCreatedTotal.WithLabelValues(string(metricsCity))))
+	})
+
+	t.Run("with location", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		lat, lon := 55.7558, 37.6173
+		req := model.CreatePvzRequest{City: city, Address: "Красная площадь, 1", Latitude: &lat, Longitude: &lon, WorkingHours: "10:00-22:00"}
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), city).Return(cityInfo, nil)
+		s.mockRepo.EXPECT().CreatePvz(gomock.Any(), req).Return(expectedPvz, nil)
+
+		_, err := s.svc.CreatePvz(ctx, req)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("longitude without latitude", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		lon := 37.6173
+
+		_, err := s.svc.CreatePvz(ctx, model.CreatePvzRequest{City: city, Longitude: &lon})
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidPvz)
+		var detailed *customErrors.DetailedError
+		require.ErrorAs(t, err, &detailed)
+		assert.Equal(t, "latitude", detailed.Details["field"])
+	})
+
+	t.Run("unknown city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), model.City("test")).Return(nil, customErrors.ErrCityDoesNotExist)
+
+		_, err := s.svc.CreatePvz(ctx, model.CreatePvzRequest{City: "test"})
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidCity)
+	})
+
+	t.Run("inactive city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), city).Return(&model.CityInfo{Name: city}, nil)
+
+		_, err := s.svc.CreatePvz(ctx, model.CreatePvzRequest{City: city})
+
+		require.ErrorIs(t, err, customErrors.ErrCityInactive)
+	})
+
 	t.Run("db error", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(nil, dbErr)
-
-		_, err := s.svc.CreatePvz(ctx, city)
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), city).Return(cityInfo, nil)
+		s.mockRepo.EXPECT().CreatePvz(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.CreatePvz(ctx, model.CreatePvzRequest{City: city})
+
+		require.EqualError(t, err, dbErr.Error())
+	})
+}
+
+func Test_UpdatePvz(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		pvzId    = uuid.New()
+		inactive = false
+		kazan    = model.CityKazan
+	)
+
+	t.Run("deactivate", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		req := model.UpdatePvzRequest{Active: &inactive}
+		s.mockRepo.EXPECT().UpdatePvz(gomock.Any(), pvzId, req).Return(&model.Pvz{Id: pvzId}, nil)
+
+		pvz, err := s.svc.UpdatePvz(ctx, pvzId, req)
+
+		require.NoError(t, err)
+		assert.False(t, pvz.Active)
+	})
+	t.Run("move to city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		req := model.UpdatePvzRequest{City: &kazan}
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), kazan).Return(&model.CityInfo{Name: kazan, Active: true}, nil)
+		s.mockRepo.EXPECT().UpdatePvz(gomock.Any(), pvzId, req).Return(&model.Pvz{Id: pvzId, City: kazan, Active: true}, nil)
+
+		pvz, err := s.svc.UpdatePvz(ctx, pvzId, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, kazan, pvz.City)
+	})
+	t.Run("inactive city", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetCity(gomock.Any(), kazan).Return(&model.CityInfo{Name: kazan}, nil)
+
+		_, err := s.svc.UpdatePvz(ctx, pvzId, model.UpdatePvzRequest{City: &kazan})
+
+		require.ErrorIs(t, err, customErrors.ErrCityInactive)
+	})
+	t.Run("pvz does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().UpdatePvz(gomock.Any(), pvzId, gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
+
+		_, err := s.svc.UpdatePvz(ctx, pvzId, model.UpdatePvzRequest{Active: &inactive})
+
+		require.ErrorIs(t, err, customErrors.ErrPvzDoesNotExist)
+	})
+}
+
+func Test_FindNearestPvz(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		query = model.NearestPvzQuery{Latitude: 55.75, Longitude: 37.62, RadiusMeters: 5000, Limit: 10}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		expected := []model.PvzDistance{{Pvz: model.Pvz{Id: uuid.New()}, DistanceMeters: 120}}
+		s.mockRepo.EXPECT().FindNearestPvz(gomock.Any(), query).Return(expected, nil)
+
+		res, err := s.svc.FindNearestPvz(ctx, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, res)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockRepo.EXPECT().FindNearestPvz(gomock.Any(), query).Return(nil, dbErr)
+
+		_, err := s.svc.FindNearestPvz(ctx, query)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_GetPvz(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		pvzId = uuid.New()
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		expected := &model.Pvz{Id: pvzId, City: model.CityMoscow}
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(expected, nil)
+
+		pvz, err := s.svc.GetPvz(ctx, pvzId)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, pvz)
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(nil, customErrors.ErrPvzDoesNotExist)
+
+		_, err := s.svc.GetPvz(ctx, pvzId)
+
+		require.ErrorIs(t, err, customErrors.ErrPvzDoesNotExist)
+	})
+}
+
+func Test_GetPvzList(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx             = context.Background()
+		expectedPvzList = []model.Pvz{{Id: uuid.New(), City: model.CityMoscow}, {Id: uuid.New(), City: model.CityKazan}}
+		dbErr           = errors.New("failed to get pvz list")
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetPvzList(gomock.Any()).Return(expectedPvzList, nil)
+
+		pvzList, err := s.svc.GetPvzList(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedPvzList, pvzList)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetPvzList(gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.GetPvzList(ctx)
+
+		require.EqualError(t, err, dbErr.Error())
+	})
+}
+
+func Test_GetPvzPage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx     = context.Background()
+		after   = &model.PvzCursor{RegistrationDate: time.Now(), Id: uuid.New()}
+		pvzPage = []model.Pvz{{Id: uuid.New(), City: model.CityMoscow}}
+		dbErr   = errors.New("failed to get pvz page")
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetPvzPage(gomock.Any(), after, int32(10)).Return(pvzPage, nil)
+
+		res, err := s.svc.GetPvzPage(ctx, after, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, pvzPage, res)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().GetPvzPage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.GetPvzPage(ctx, after, 10)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_CloseLastReception(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx               = context.Background()
+		pvzId             = uuid.New()
+		pvz               = &model.Pvz{Id: pvzId, City: model.CityMoscow}
+		curReception      = &model.Reception{Id: uuid.New(), PvzId: pvzId, Status: model.ReceptionStatusInProgress}
+		expectedReception = &model.Reception{Id: curReception.Id, PvzId: pvzId, Status: model.ReceptionStatusClose}
+		dbErr             = errors.New("db error")
+	)
+
+	t.Run("succes", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(curReception, nil)
+		s.mockRepo.EXPECT().UpdateReceptionStatus(gomock.Any(), gomock.Any(), curReception.Id, model.ReceptionStatusClose, testEmployee.Id).Return(expectedReception, nil)
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), model.ReceptionTransition{
+			ReceptionId: curReception.Id,
+			FromStatus:  model.ReceptionStatusInProgress,
+			ToStatus:    model.ReceptionStatusClose,
+			ActorId:     testEmployee.Id,
+			ActorRole:   testEmployee.Role,
+		}).Return(&model.ReceptionTransition{}, nil)
+
+		rec, err := s.svc.CloseLastReception(ctx, pvzId, testEmployee)
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedReception, rec)
+	})
+
+	t.Run("pvz doed not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
+
+		_, err := s.svc.CloseLastReception(ctx, pvzId, testEmployee)
+
+		require.EqualError(t, err, customErrors.ErrPvzDoesNotExist.Error())
+	})
+
+	t.Run("no reception in progress", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := s.svc.CloseLastReception(ctx, pvzId, testEmployee)
+
+		require.EqualError(t, err, customErrors.ErrReceptionInProgressDoesNotExist.Error())
+	})
+	t.Run("failed transaction", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
+
+		_, err := s.svc.CloseLastReception(ctx, pvzId, testEmployee)
+
+		require.Error(t, err)
+	})
+	t.Run("failed to update reception status", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(curReception, nil)
+		s.mockRepo.EXPECT().UpdateReceptionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.CloseLastReception(ctx, pvzId, testEmployee)
+
+		require.Error(t, err)
+	})
+	t.Run("failed to record transition", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(curReception, nil)
+		s.mockRepo.EXPECT().UpdateReceptionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedReception, nil)
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.CloseLastReception(ctx, pvzId, testEmployee)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_CancelReception(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		pvzId       = uuid.New()
+		receptionId = uuid.New()
+		pvz         = &model.Pvz{Id: pvzId, City: model.CityMoscow}
+		dbErr       = errors.New("db error")
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var metricsCity model.City = "cancel-test-city"
+		s := setUp(t)
+		defer s.tearDown()
+		cancelled := &model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusCancelled}
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), receptionId).
+			Return(&model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusInProgress}, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(&model.Pvz{Id: pvzId, City: metricsCity}, nil)
+		s.mockRepo.EXPECT().UpdateReceptionStatus(gomock.Any(), gomock.Any(), receptionId, model.ReceptionStatusCancelled, testEmployee.Id).Return(cancelled, nil)
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.ReceptionTransition{}, nil)
+		before := testutil.ToFloat64(metrics.ReceptionsCancelledTotal.WithLabelValues(string(metricsCity)))
+
+		rec, err := s.svc.CancelReception(ctx, receptionId, testEmployee)
+
+		require.NoError(t, err)
+		assert.Equal(t, cancelled, rec)
+		assert.Equal(t, before+1, testutil.ToFloat64(metrics.ReceptionsCancelledTotal.WithLabelValues(string(metricsCity))))
+	})
+	t.Run("moderator is not allowed", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+
+		_, err := s.svc.CancelReception(ctx, receptionId, testModerator)
+
+		require.ErrorIs(t, err, customErrors.ErrAccessDenied)
+	})
+	t.Run("reception does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionDoesNotExist)
+
+		_, err := s.svc.CancelReception(ctx, receptionId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrReceptionDoesNotExist)
+	})
+	t.Run("closed reception", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusClose}, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+
+		_, err := s.svc.CancelReception(ctx, receptionId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidReceptionTransition)
+	})
+	t.Run("failed to get reception", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.CancelReception(ctx, receptionId, testEmployee)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_ReopenReception(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		pvzId       = uuid.New()
+		receptionId = uuid.New()
+		pvz         = &model.Pvz{Id: pvzId, City: model.CityMoscow, Active: true}
+		closed      = &model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusClose}
+		reopened    = &model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusInProgress}
+		dbErr       = errors.New("db error")
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), receptionId).Return(closed, nil)
+		s.mockRepo.EXPECT().ReceptionClosedWithin(gomock.Any(), gomock.Any(), receptionId, model.ReceptionReopenWindow).Return(true, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(pvz, nil)
+		s.mockRepo.EXPECT().UpdateReceptionStatus(gomock.Any(), gomock.Any(), receptionId, model.ReceptionStatusInProgress, testModerator.Id).Return(reopened, nil)
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), model.ReceptionTransition{
+			ReceptionId: receptionId,
+			FromStatus:  model.ReceptionStatusClose,
+			ToStatus:    model.ReceptionStatusInProgress,
+			ActorId:     testModerator.Id,
+			ActorRole:   testModerator.Role,
+		}).Return(&model.ReceptionTransition{}, nil)
+
+		rec, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
 
-		require.EqualError(t, err, dbErr.Error())
+		require.NoError(t, err)
+		assert.Equal(t, reopened, rec)
 	})
-}
+	t.Run("employee is not allowed", func(t *testing.T) {
+		t.Parallel()
 
-func Test_CloseLastReception(t *testing.T) {
-	t.Parallel()
+		s := setUp(t)
+		defer s.tearDown()
 
-	var (
-		ctx               = context.Background()
-		pvzId             = uuid.New()
-		expectedReception = &model.Reception{PvzId: pvzId}
-		dbErr             = errors.New("db error")
-	)
+		_, err := s.svc.ReopenReception(ctx, receptionId, testEmployee)
 
-	t.Run("succes", func(t *testing.T) {
+		require.ErrorIs(t, err, customErrors.ErrAccessDenied)
+	})
+	t.Run("pvz inactive", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().UpdateLastReceptionStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedReception, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), receptionId).Return(closed, nil)
+		s.mockRepo.EXPECT().ReceptionClosedWithin(gomock.Any(), gomock.Any(), receptionId, model.ReceptionReopenWindow).Return(true, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(&model.Pvz{Id: pvzId, City: model.CityMoscow}, nil)
 
-		rec, err := s.svc.CloseLastReception(ctx, pvzId)
+		_, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
 
-		require.NoError(t, err)
-		assert.Equal(t, expectedReception, rec)
+		require.ErrorIs(t, err, customErrors.ErrPvzInactive)
 	})
-
-	t.Run("pvz doed not exist", func(t *testing.T) {
+	t.Run("window expired", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(closed, nil)
+		s.mockRepo.EXPECT().ReceptionClosedWithin(gomock.Any(), gomock.Any(), receptionId, model.ReceptionReopenWindow).Return(false, nil)
 
-		_, err := s.svc.CloseLastReception(ctx, pvzId)
+		_, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
 
-		require.EqualError(t, err, customErrors.ErrPvzDoesNotExist.Error())
+		require.ErrorIs(t, err, customErrors.ErrReceptionReopenWindowExpired)
 	})
+	t.Run("failed to check close time", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("no reception in progress", func(t *testing.T) {
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(closed, nil)
+		s.mockRepo.EXPECT().ReceptionClosedWithin(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, dbErr)
+
+		_, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+	t.Run("cancelled reception", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().UpdateLastReceptionStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusCancelled}, nil)
 
-		_, err := s.svc.CloseLastReception(ctx, pvzId)
+		_, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
 
-		require.EqualError(t, err, customErrors.ErrReceptionInProgressDoesNotExist.Error())
+		require.ErrorIs(t, err, customErrors.ErrInvalidReceptionTransition)
 	})
-	t.Run("failed begin transaction", func(t *testing.T) {
+	t.Run("another reception in progress", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(closed, nil)
+		s.mockRepo.EXPECT().ReceptionClosedWithin(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().UpdateReceptionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, customErrors.ErrReceptionInProgressAlreadyExists)
 
-		_, err := s.svc.CloseLastReception(ctx, pvzId)
+		_, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
 
-		require.Error(t, err)
+		require.ErrorIs(t, err, customErrors.ErrReceptionInProgressAlreadyExists)
 	})
-	t.Run("failed to update reception status", func(t *testing.T) {
+	t.Run("failed to get reception", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().UpdateLastReceptionStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
 
-		_, err := s.svc.CloseLastReception(ctx, pvzId)
+		_, err := s.svc.ReopenReception(ctx, receptionId, testModerator)
 
-		require.Error(t, err)
+		require.ErrorIs(t, err, dbErr)
 	})
 }
 
@@ -137,8 +649,11 @@ func Test_DeleteLastProduct(t *testing.T) {
 	var (
 		ctx   = context.Background()
 		pvzId = uuid.New()
-		rec   = &model.Reception{PvzId: pvzId}
+		pvz   = &model.Pvz{Id: pvzId, City: model.CityMoscow}
+		rec   = &model.Reception{Id: uuid.New(), PvzId: pvzId}
 		dbErr = errors.New("db error")
+
+		product = &model.Product{Id: uuid.New(), DateTime: time.Now(), Type: model.ProductTypeClothes}
 	)
 
 	t.Run("success", func(t *testing.T) {
@@ -146,13 +661,20 @@ func Test_DeleteLastProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
-		s.mockRepo.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
-
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		s.mockRepo.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), rec.Id).Return(product, nil)
+		s.mockRepo.EXPECT().CreateProductDeletion(gomock.Any(), gomock.Any(), model.ProductDeletion{
+			ProductId:       product.Id,
+			ProductType:     product.Type,
+			ProductDateTime: product.DateTime,
+			ReceptionId:     rec.Id,
+			ActorId:         testEmployee.Id,
+			ActorRole:       testEmployee.Role,
+		}).Return(nil)
+
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.NoError(t, err)
 	})
@@ -162,11 +684,10 @@ func Test_DeleteLastProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
 		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.EqualError(t, err, customErrors.ErrPvzDoesNotExist.Error())
 	})
@@ -176,12 +697,11 @@ func Test_DeleteLastProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
 
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.EqualError(t, err, customErrors.ErrReceptionInProgressDoesNotExist.Error())
 	})
@@ -191,13 +711,12 @@ func Test_DeleteLastProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
-		s.mockRepo.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(customErrors.ErrNoProductToDelete)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrNoProductToDelete)
 
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.EqualError(t, err, customErrors.ErrNoProductToDelete.Error())
 	})
@@ -206,23 +725,22 @@ func Test_DeleteLastProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.Error(t, err)
 	})
-	t.Run("failed begin transaction", func(t *testing.T) {
+	t.Run("failed transaction", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
 
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.Error(t, err)
 	})
@@ -231,24 +749,116 @@ func Test_DeleteLastProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
-		s.mockRepo.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().DeleteLastProduct(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
 
-		err := s.svc.DeleteLastProduct(ctx, pvzId)
+		err := s.svc.DeleteLastProduct(ctx, pvzId, testEmployee)
 
 		require.Error(t, err)
 	})
 }
 
+func Test_DeleteProduct(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx         = context.Background()
+		pvzId       = uuid.New()
+		receptionId = uuid.New()
+		productId   = uuid.New()
+		pvz         = &model.Pvz{Id: pvzId, City: model.CityMoscow}
+		inProgress  = &model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusInProgress}
+		product     = &model.Product{Id: productId, DateTime: time.Now(), Type: model.ProductTypeShoes, ReceptionId: receptionId.String()}
+		dbErr       = errors.New("db error")
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), receptionId).Return(inProgress, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(pvz, nil)
+		s.mockRepo.EXPECT().DeleteProduct(gomock.Any(), gomock.Any(), receptionId, productId).Return(product, nil)
+		s.mockRepo.EXPECT().CreateProductDeletion(gomock.Any(), gomock.Any(), model.ProductDeletion{
+			ProductId:       productId,
+			ProductType:     model.ProductTypeShoes,
+			ProductDateTime: product.DateTime,
+			ReceptionId:     receptionId,
+			ActorId:         testEmployee.Id,
+			ActorRole:       testEmployee.Role,
+		}).Return(nil)
+
+		err := s.svc.DeleteProduct(ctx, receptionId, productId, testEmployee)
+
+		require.NoError(t, err)
+	})
+	t.Run("reception does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionDoesNotExist)
+
+		err := s.svc.DeleteProduct(ctx, receptionId, productId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrReceptionDoesNotExist)
+	})
+	t.Run("reception is closed", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&model.Reception{Id: receptionId, PvzId: pvzId, Status: model.ReceptionStatusClose}, nil)
+
+		err := s.svc.DeleteProduct(ctx, receptionId, productId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrReceptionNotInProgress)
+	})
+	t.Run("product does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(inProgress, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().DeleteProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrProductDoesNotExist)
+
+		err := s.svc.DeleteProduct(ctx, receptionId, productId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrProductDoesNotExist)
+	})
+	t.Run("failed to record deletion", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(inProgress, nil)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().DeleteProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(product, nil)
+		s.mockRepo.EXPECT().CreateProductDeletion(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
+
+		err := s.svc.DeleteProduct(ctx, receptionId, productId, testEmployee)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
 func Test_CreateReception(t *testing.T) {
 	t.Parallel()
 
 	var (
 		ctx         = context.Background()
 		pvzId       = uuid.New()
+		pvz         = &model.Pvz{Id: pvzId, City: model.CityMoscow, Active: true}
 		expectedRec = &model.Reception{PvzId: pvzId}
 		curRec      = &model.Reception{}
 		dbErr       = errors.New("db error")
@@ -259,13 +869,13 @@ func Test_CreateReception(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		s.mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedRec, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.ReceptionTransition{}, nil)
 
-		rec, err := s.svc.CreateReception(ctx, pvzId)
+		rec, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedRec, rec)
@@ -276,13 +886,13 @@ func Test_CreateReception(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		s.mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedRec, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.ReceptionTransition{}, nil)
 
-		rec, err := s.svc.CreateReception(ctx, pvzId)
+		rec, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedRec, rec)
@@ -292,25 +902,35 @@ func Test_CreateReception(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
 		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.CreateReception(ctx, pvzId)
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.EqualError(t, err, customErrors.ErrPvzDoesNotExist.Error())
 	})
+	t.Run("pvz inactive", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.Pvz{Id: pvzId}, nil)
+
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrPvzInactive)
+	})
 	t.Run("reception in progress already exist", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(curRec, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.CreateReception(ctx, pvzId)
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.EqualError(t, err, customErrors.ErrReceptionInProgressAlreadyExists.Error())
 	})
@@ -319,23 +939,22 @@ func Test_CreateReception(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.CreateReception(ctx, pvzId)
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.Error(t, err)
 	})
-	t.Run("failed begin transaction", func(t *testing.T) {
+	t.Run("failed transaction", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
 
-		_, err := s.svc.CreateReception(ctx, pvzId)
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.Error(t, err)
 	})
@@ -344,16 +963,52 @@ func Test_CreateReception(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
 		s.mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.CreateReception(ctx, pvzId)
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
 
 		require.Error(t, err)
 	})
+	t.Run("reception created concurrently", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrReceptionInProgressAlreadyExists)
+
+		_, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
+
+		require.ErrorIs(t, err, customErrors.ErrReceptionInProgressAlreadyExists)
+	})
+	t.Run("commit failure after work", func(t *testing.T) {
+		t.Parallel()
+
+		var metricsCity model.City = "commit-failure-city"
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, options *pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+				require.NoError(t, fn(nil))
+				return dbErr
+			})
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.Pvz{Id: pvzId, City: metricsCity, Active: true}, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedRec, nil)
+		s.mockRepo.EXPECT().CreateReceptionTransition(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.ReceptionTransition{}, nil)
+		before := testutil.ToFloat64(metrics.ReceptionsCreatedTotal.WithLabelValues(string(metricsCity)))
+
+		rec, err := s.svc.CreateReception(ctx, pvzId, testEmployee)
+
+		require.ErrorIs(t, err, dbErr)
+		assert.Nil(t, rec)
+		assert.Equal(t, before, testutil.ToFloat64(metrics.ReceptionsCreatedTotal.WithLabelValues(string(metricsCity))))
+	})
 }
 
 func Test_AddProduct(t *testing.T) {
@@ -362,6 +1017,7 @@ func Test_AddProduct(t *testing.T) {
 	var (
 		ctx             = context.Background()
 		pvzId           = uuid.New()
+		pvz             = &model.Pvz{Id: pvzId, City: model.CityMoscow, Active: true}
 		productType     = model.ProductTypeClothes
 		expectedProduct = &model.Product{Type: productType}
 		rec             = &model.Reception{PvzId: pvzId}
@@ -373,41 +1029,71 @@ func Test_AddProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
-		s.mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedProduct, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(expectedProduct, nil)
 
-		product, err := s.svc.AddProduct(ctx, pvzId, productType)
+		product, err := s.svc.AddProduct(ctx, pvzId, productType, "")
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedProduct, product)
 	})
+	t.Run("product type deleted meanwhile", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrInvalidProductType)
+
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+		_, _, cached := s.svc.productTypes.get(time.Now())
+		assert.False(t, cached)
+	})
 	t.Run("pvz does not exist", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
 		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.AddProduct(ctx, pvzId, productType)
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
 
 		require.EqualError(t, err, customErrors.ErrPvzDoesNotExist.Error())
 	})
+	t.Run("pvz inactive", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.Pvz{Id: pvzId}, nil)
+
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
+
+		require.ErrorIs(t, err, customErrors.ErrPvzInactive)
+	})
 	t.Run("no reception in progress", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.AddProduct(ctx, pvzId, productType)
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
 
 		require.EqualError(t, err, customErrors.ErrReceptionInProgressDoesNotExist.Error())
 	})
@@ -416,23 +1102,24 @@ func Test_AddProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
 
-		_, err := s.svc.AddProduct(ctx, pvzId, productType)
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
 
 		require.Error(t, err)
 	})
-	t.Run("failed begin transaction", func(t *testing.T) {
+	t.Run("failed transaction", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
 
-		_, err := s.svc.AddProduct(ctx, pvzId, productType)
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
 
 		require.Error(t, err)
 	})
@@ -441,16 +1128,262 @@ func Test_AddProduct(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
 		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
-		s.mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
 
-		_, err := s.svc.AddProduct(ctx, pvzId, productType)
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
 
 		require.Error(t, err)
 	})
+	t.Run("type from catalog", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		barcode := "4601234567893"
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemByBarcode(gomock.Any(), gomock.Any(), barcode).
+			Return(&model.CatalogItem{Barcode: barcode, Type: model.ProductTypeShoes}, nil)
+		s.mockRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), model.ProductTypeShoes, barcode).
+			Return(&model.Product{Type: model.ProductTypeShoes, Barcode: barcode}, nil)
+
+		product, err := s.svc.AddProduct(ctx, pvzId, "", barcode)
+
+		require.NoError(t, err)
+		assert.Equal(t, model.ProductTypeShoes, product.Type)
+	})
+	t.Run("type does not match catalog", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		barcode := "4601234567893"
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemByBarcode(gomock.Any(), gomock.Any(), barcode).
+			Return(&model.CatalogItem{Barcode: barcode, Type: model.ProductTypeShoes}, nil)
+
+		_, err := s.svc.AddProduct(ctx, pvzId, model.ProductTypeClothes, barcode)
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+	})
+	t.Run("barcode not in catalog", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemByBarcode(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrCatalogItemDoesNotExist)
+
+		_, err := s.svc.AddProduct(ctx, pvzId, "", "4601234567893")
+
+		require.ErrorIs(t, err, customErrors.ErrCatalogItemDoesNotExist)
+	})
+	t.Run("unknown product type", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+
+		_, err := s.svc.AddProduct(ctx, pvzId, "книги", "")
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+	})
+	t.Run("failed to load product types", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockRepo.EXPECT().ListProductTypes(gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.AddProduct(ctx, pvzId, productType, "")
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_AddProducts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		pvzId    = uuid.New()
+		pvz      = &model.Pvz{Id: pvzId, City: model.CityMoscow, Active: true}
+		rec      = &model.Reception{Id: uuid.New(), PvzId: pvzId}
+		items    = []model.ProductBatchItem{{Type: model.ProductTypeShoes}, {Type: model.ProductTypeClothes, Barcode: "4601234567893"}}
+		products = []model.Product{{Type: model.ProductTypeShoes}, {Type: model.ProductTypeClothes, Barcode: "4601234567893"}}
+		dbErr    = errors.New("db error")
+		shirt    = &model.CatalogItem{Barcode: "4601234567893", Type: model.ProductTypeClothes}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), pvzId).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemsByBarcodes(gomock.Any(), gomock.Any(), []string{shirt.Barcode}).Return([]model.CatalogItem{*shirt}, nil)
+		s.mockRepo.EXPECT().AddProducts(gomock.Any(), gomock.Any(), rec.Id, items).Return(products, nil)
+
+		res, err := s.svc.AddProducts(ctx, pvzId, items)
+
+		require.NoError(t, err)
+		assert.Equal(t, products, res)
+	})
+	t.Run("barcode only items take the catalog type", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		batch := []model.ProductBatchItem{{Type: model.ProductTypeShoes}, {Barcode: shirt.Barcode}}
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), pvzId).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), pvzId).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemsByBarcodes(gomock.Any(), gomock.Any(), []string{shirt.Barcode}).Return([]model.CatalogItem{*shirt}, nil)
+		s.mockRepo.EXPECT().AddProducts(gomock.Any(), gomock.Any(), rec.Id, items).Return(products, nil)
+
+		res, err := s.svc.AddProducts(ctx, pvzId, batch)
+
+		require.NoError(t, err)
+		assert.Equal(t, products, res)
+		assert.Empty(t, batch[1].Type)
+	})
+	t.Run("pvz does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrPvzDoesNotExist)
+
+		_, err := s.svc.AddProducts(ctx, pvzId, items)
+
+		require.ErrorIs(t, err, customErrors.ErrPvzDoesNotExist)
+	})
+	t.Run("pvz inactive", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(&model.Pvz{Id: pvzId}, nil)
+
+		_, err := s.svc.AddProducts(ctx, pvzId, items)
+
+		require.ErrorIs(t, err, customErrors.ErrPvzInactive)
+	})
+	t.Run("no reception in progress", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		_, err := s.svc.AddProducts(ctx, pvzId, items)
+
+		require.ErrorIs(t, err, customErrors.ErrReceptionInProgressDoesNotExist)
+	})
+	t.Run("failed to add products", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemsByBarcodes(gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.CatalogItem{*shirt}, nil)
+		s.mockRepo.EXPECT().AddProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.AddProducts(ctx, pvzId, items)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+	t.Run("per item errors", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		invalid := []model.ProductBatchItem{
+			{Type: model.ProductTypeShoes},
+			{Type: "книги"},
+			{Type: model.ProductTypeClothes, Barcode: "12ab"},
+		}
+
+		_, err := s.svc.AddProducts(ctx, pvzId, invalid)
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductBatch)
+		var detailed *customErrors.DetailedError
+		require.ErrorAs(t, err, &detailed)
+		assert.Equal(t, []model.BatchItemError{
+			{Index: 1, Code: "invalid_product_type", Message: customErrors.ErrInvalidProductType.Error()},
+			{Index: 2, Code: "invalid_barcode", Message: customErrors.ErrInvalidBarcode.Error()},
+		}, detailed.Details["items"])
+	})
+	t.Run("per item catalog errors", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		batch := []model.ProductBatchItem{
+			{Type: model.ProductTypeClothes, Barcode: shirt.Barcode},
+			{Type: model.ProductTypeShoes, Barcode: shirt.Barcode},
+			{Type: model.ProductTypeShoes, Barcode: "4600000000000"},
+		}
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemsByBarcodes(gomock.Any(), gomock.Any(), []string{shirt.Barcode, shirt.Barcode, "4600000000000"}).
+			Return([]model.CatalogItem{*shirt}, nil)
+
+		_, err := s.svc.AddProducts(ctx, pvzId, batch)
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidProductBatch)
+		var detailed *customErrors.DetailedError
+		require.ErrorAs(t, err, &detailed)
+		assert.Equal(t, []model.BatchItemError{
+			{Index: 1, Code: "invalid_product_type", Message: customErrors.ErrInvalidProductType.Error()},
+			{Index: 2, Code: "catalog_item_not_found", Message: customErrors.ErrCatalogItemDoesNotExist.Error()},
+		}, detailed.Details["items"])
+	})
+	t.Run("catalog db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.expectProductTypes()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(pvz, nil)
+		s.mockRepo.EXPECT().GetCurrentReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(rec, nil)
+		s.mockRepo.EXPECT().GetCatalogItemsByBarcodes(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.svc.AddProducts(ctx, pvzId, items)
+
+		require.ErrorIs(t, err, dbErr)
+	})
 }
 
 func Test_GetPvzInfo(t *testing.T) {
@@ -458,15 +1391,11 @@ func Test_GetPvzInfo(t *testing.T) {
 
 	var (
 		ctx           = context.Background()
-		page          = int32(1)
-		limit         = int32(10)
-		startDate     = time.Time{}
-		endDate       = time.Now()
+		filter        = model.PvzInfoFilter{StartDate: time.Time{}, EndDate: time.Now(), Page: 2, Limit: 10}
 		pvzId         = uuid.New()
 		dbErr         = errors.New("db error")
 		pvz           = model.Pvz{Id: pvzId}
 		reception     = model.Reception{PvzId: pvzId}
-		receptions    = []model.Reception{reception}
 		product1      = model.Product{Type: model.ProductTypeClothes}
 		product2      = model.Product{Type: model.ProductTypeElectronics}
 		products      = []model.Product{product1, product2}
@@ -474,14 +1403,15 @@ func Test_GetPvzInfo(t *testing.T) {
 			Reception: reception,
 			Products:  products,
 		}
-		expectedRes = &model.GetPvzInfoResponse{
-			PvzList: []model.PvzInfo{
-				{
-					Pvz:        pvz,
-					Receptions: []model.ReceptionInfo{receptionInfo},
-				},
+		pvzList = []model.PvzInfo{
+			{
+				Pvz:        pvz,
+				Receptions: []model.ReceptionInfo{receptionInfo},
 			},
 		}
+		expectedRes = &model.GetPvzInfoResponse{
+			PvzList: pvzList,
+		}
 	)
 
 	t.Run("success", func(t *testing.T) {
@@ -489,67 +1419,51 @@ func Test_GetPvzInfo(t *testing.T) {
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetReceptionsForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(receptions, nil)
-		s.mockRepo.EXPECT().GetProductsInReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(products, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(&pvz, nil)
-		s.mockRepo.EXPECT().CommitTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvzInfoForPeriod(gomock.Any(), gomock.Any(), filter).Return(pvzList, nil)
 
-		res, err := s.svc.GetPvzInfo(ctx, startDate, endDate, page, limit)
+		res, err := s.svc.GetPvzInfo(ctx, filter)
 
 		require.NoError(t, err)
 		assert.Equal(t, expectedRes, res)
 	})
-	t.Run("failed begin transaction", func(t *testing.T) {
-		t.Parallel()
-
-		s := setUp(t)
-		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, dbErr)
-
-		_, err := s.svc.GetPvzInfo(ctx, startDate, endDate, page, limit)
-
-		require.Error(t, err)
-	})
-	t.Run("failed to get receptions for period", func(t *testing.T) {
+	t.Run("full page returns next cursor", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetReceptionsForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		fullPageFilter := filter
+		fullPageFilter.Limit = int32(len(pvzList))
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvzInfoForPeriod(gomock.Any(), gomock.Any(), fullPageFilter).Return(pvzList, nil)
 
-		_, err := s.svc.GetPvzInfo(ctx, startDate, endDate, page, limit)
+		res, err := s.svc.GetPvzInfo(ctx, fullPageFilter)
 
-		require.Error(t, err)
+		require.NoError(t, err)
+		cursor, err := model.DecodePvzCursor(res.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, pvzId, cursor.Id)
 	})
-	t.Run("failed to get products in reception", func(t *testing.T) {
+	t.Run("failed transaction", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetReceptionsForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(receptions, nil)
-		s.mockRepo.EXPECT().GetProductsInReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
 
-		_, err := s.svc.GetPvzInfo(ctx, startDate, endDate, page, limit)
+		_, err := s.svc.GetPvzInfo(ctx, filter)
 
 		require.Error(t, err)
 	})
-	t.Run("failed to get pvz", func(t *testing.T) {
+	t.Run("failed to get pvz info for period", func(t *testing.T) {
 		t.Parallel()
 
 		s := setUp(t)
 		defer s.tearDown()
-		s.mockRepo.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(nil, nil)
-		s.mockRepo.EXPECT().GetReceptionsForPeriod(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(receptions, nil)
-		s.mockRepo.EXPECT().GetProductsInReception(gomock.Any(), gomock.Any(), gomock.Any()).Return(products, nil)
-		s.mockRepo.EXPECT().GetPvz(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
-		s.mockRepo.EXPECT().RollbackTx(gomock.Any(), gomock.Any()).Return()
+		s.mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+		s.mockRepo.EXPECT().GetPvzInfoForPeriod(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
 
-		_, err := s.svc.GetPvzInfo(ctx, startDate, endDate, page, limit)
+		_, err := s.svc.GetPvzInfo(ctx, filter)
 
 		require.Error(t, err)
 	})
@@ -1,29 +1,60 @@
 package service
 
 import (
+	"avito2/internal/model"
 	mock_repository "avito2/internal/repository/mocks"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	testEmployee  = model.Actor{Id: uuid.NewString(), Role: model.RoleEmployee}
+	testModerator = model.Actor{Id: uuid.NewString(), Role: model.RoleModerator}
 )
 
 type serviceFixtures struct {
-	ctrl     *gomock.Controller
-	svc      *Svc
-	mockRepo *mock_repository.MockRepository
+	ctrl         *gomock.Controller
+	svc          *Svc
+	userSvc      *UserSvc
+	mockRepo     *mock_repository.MockRepository
+	mockUserRepo *mock_repository.MockUserRepository
 }
 
 func setUp(t *testing.T) serviceFixtures {
 	ctrl := gomock.NewController(t)
 	mockRepo := mock_repository.NewMockRepository(ctrl)
+	mockUserRepo := mock_repository.NewMockUserRepository(ctrl)
 	svc := NewService(mockRepo)
+	userSvc := NewUserService(mockUserRepo, time.Hour)
 	return serviceFixtures{
-		ctrl:     ctrl,
-		svc:      svc,
-		mockRepo: mockRepo,
+		ctrl:         ctrl,
+		svc:          svc,
+		userSvc:      userSvc,
+		mockRepo:     mockRepo,
+		mockUserRepo: mockUserRepo,
 	}
 }
 
+// expectProductTypes makes the product type cache load the seeded types.
+func (s *serviceFixtures) expectProductTypes() {
+	s.mockRepo.EXPECT().ListProductTypes(gomock.Any()).Return([]model.ProductTypeInfo{
+		{Name: model.ProductTypeElectronics},
+		{Name: model.ProductTypeClothes},
+		{Name: model.ProductTypeShoes},
+	}, nil).AnyTimes()
+}
+
 func (s *serviceFixtures) tearDown() {
 	s.ctrl.Finish()
 }
+
+// runInTx stands in for Repository.WithTx in mocks and runs fn without a real
+// transaction.
+func runInTx(_ context.Context, _ *pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	return fn(nil)
+}
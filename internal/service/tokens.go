@@ -0,0 +1,251 @@
+package service
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/model"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// denylistRefreshInterval bounds how long an access token revoked through
+// another instance stays usable here. Revocations made through this instance
+// apply at once.
+const denylistRefreshInterval = 10 * time.Second
+
+// TokenCleanupInterval is how often expired refresh tokens and revoked access
+// token ids are deleted.
+const TokenCleanupInterval = time.Hour
+
+// tokenDenylist keeps the ids of revoked, not yet expired access tokens in
+// memory so that authenticating a request does not query the database.
+type tokenDenylist struct {
+	mu       sync.RWMutex
+	revoked  map[string]time.Time
+	loadedAt time.Time
+	// loading is closed when the reload in progress finishes, loadErr is its
+	// result.
+	loading chan struct{}
+	loadErr error
+}
+
+// contains reports whether the token is revoked according to the loaded
+// denylist and whether that copy is still fresh.
+func (d *tokenDenylist) contains(tokenId string, now time.Time) (revoked, fresh bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	expiresAt, ok := d.revoked[tokenId]
+	return ok && now.Before(expiresAt), d.revoked != nil && now.Sub(d.loadedAt) <= denylistRefreshInterval
+}
+
+// reload refreshes the denylist unless it is fresh. The database is queried
+// without holding the lock and by one caller at a time; the others keep using
+// the stale copy meanwhile and only wait if nothing was loaded yet. If the
+// database is unavailable the previous copy stays in use until the next
+// interval; only a denylist that was never loaded fails.
+func (d *tokenDenylist) reload(ctx context.Context, now time.Time, list func(ctx context.Context, now time.Time) ([]model.RevokedToken, error)) error {
+	d.mu.Lock()
+	if d.revoked != nil && now.Sub(d.loadedAt) <= denylistRefreshInterval {
+		d.mu.Unlock()
+		return nil
+	}
+	if loading := d.loading; loading != nil {
+		loaded := d.revoked != nil
+		d.mu.Unlock()
+		if loaded {
+			return nil
+		}
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		return d.loadErr
+	}
+	loading := make(chan struct{})
+	d.loading = loading
+	d.mu.Unlock()
+
+	tokens, err := list(ctx, now)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	defer close(loading)
+	d.loading = nil
+	d.loadErr = err
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to list revoked tokens", "err", err)
+		if d.revoked == nil {
+			return err
+		}
+		d.loadedAt = now
+		return nil
+	}
+
+	// Revocations are final, so the tokens of the previous copy that have not
+	// expired stay: they may have been added while the query was running.
+	revoked := make(map[string]time.Time, len(tokens))
+	for id, expiresAt := range d.revoked {
+		if now.Before(expiresAt) {
+			revoked[id] = expiresAt
+		}
+	}
+	for _, token := range tokens {
+		revoked[token.Id] = token.ExpiresAt
+	}
+	d.revoked = revoked
+	d.loadedAt = now
+	return nil
+}
+
+func (d *tokenDenylist) add(token model.RevokedToken) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.revoked != nil {
+		d.revoked[token.Id] = token.ExpiresAt
+	}
+}
+
+// IsRevoked reports whether the access token with the jti was revoked.
+func (s *UserSvc) IsRevoked(ctx context.Context, tokenId string) (bool, error) {
+	now := time.Now()
+	if revoked, fresh := s.denylist.contains(tokenId, now); fresh {
+		return revoked, nil
+	}
+
+	if err := s.denylist.reload(ctx, now, s.repo.ListRevokedAccessTokens); err != nil {
+		return false, err
+	}
+	revoked, _ := s.denylist.contains(tokenId, now)
+	return revoked, nil
+}
+
+// IssueRefreshToken starts a new refresh token family for a signed in user and
+// returns the token to hand to the client.
+func (s *UserSvc) IssueRefreshToken(ctx context.Context, userId string, role model.Role) (string, error) {
+	token, hash, err := newRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate refresh token", "err", err)
+		return "", err
+	}
+
+	now := time.Now()
+	_, err = s.repo.CreateRefreshToken(ctx, hash, model.RefreshToken{
+		FamilyId:  uuid.New(),
+		UserId:    userId,
+		Role:      role,
+		ExpiresAt: now.Add(s.refreshTokenTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to create refresh token", "err", err)
+		return "", err
+	}
+	return token, nil
+}
+
+// RefreshSession exchanges a refresh token for a new one and returns the
+// owner of the session. Presenting a token that was already exchanged means
+// it leaked, so the whole family is revoked and the owner has to sign in
+// again.
+func (s *UserSvc) RefreshSession(ctx context.Context, refreshToken string) (*model.RefreshToken, string, error) {
+	newToken, newHash, err := newRefreshToken()
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to generate refresh token", "err", err)
+		return nil, "", err
+	}
+
+	now := time.Now()
+	hash := hashRefreshToken(refreshToken)
+	session, err := s.repo.RotateRefreshToken(ctx, hash, newHash, now, now.Add(s.refreshTokenTTL))
+	if err == errors.ErrInvalidRefreshToken {
+		if err := s.repo.RevokeRefreshTokenFamily(ctx, hash, now); err != nil {
+			logger.FromContext(ctx).Error("failed to revoke refresh token family", "err", err)
+			return nil, "", err
+		}
+		return nil, "", errors.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to rotate refresh token", "err", err)
+		return nil, "", err
+	}
+	return session, newToken, nil
+}
+
+// Logout revokes the refresh token family, if a refresh token is given, and
+// the access token the request was made with. A refresh token of another user
+// is rejected with errors.ErrAccessDenied.
+func (s *UserSvc) Logout(ctx context.Context, userId, refreshToken string, accessToken model.RevokedToken) error {
+	now := time.Now()
+	if refreshToken != "" {
+		hash := hashRefreshToken(refreshToken)
+		session, err := s.repo.GetRefreshToken(ctx, hash)
+		if err != nil {
+			logger.FromContext(ctx).Error("failed to get refresh token", "err", err)
+			return err
+		}
+		if session != nil && session.UserId != userId {
+			return errors.ErrAccessDenied
+		}
+
+		if err := s.repo.RevokeRefreshTokenFamily(ctx, hash, now); err != nil {
+			logger.FromContext(ctx).Error("failed to revoke refresh token family", "err", err)
+			return err
+		}
+	}
+
+	if err := s.repo.RevokeAccessToken(ctx, accessToken); err != nil {
+		logger.FromContext(ctx).Error("failed to revoke access token", "err", err)
+		return err
+	}
+	s.denylist.add(accessToken)
+	return nil
+}
+
+// DeleteExpiredTokens deletes the refresh tokens and revoked access token ids
+// that have expired.
+func (s *UserSvc) DeleteExpiredTokens(ctx context.Context) error {
+	if _, err := s.repo.DeleteExpiredTokens(ctx, time.Now()); err != nil {
+		logger.FromContext(ctx).Error("failed to delete expired tokens", "err", err)
+		return err
+	}
+	return nil
+}
+
+// RunTokenCleanup calls DeleteExpiredTokens every interval until ctx is done.
+// Failures are logged and retried at the next tick.
+func (s *UserSvc) RunTokenCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.DeleteExpiredTokens(ctx)
+		}
+	}
+}
+
+func newRefreshToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
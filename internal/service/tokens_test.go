@@ -0,0 +1,378 @@
+package service
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_IssueRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userId := uuid.NewString()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		var storedHash string
+		s.mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, hash string, token model.RefreshToken) (*model.RefreshToken, error) {
+				storedHash = hash
+				assert.Equal(t, userId, token.UserId)
+				assert.Equal(t, model.RoleEmployee, token.Role)
+				assert.NotEqual(t, uuid.Nil, token.FamilyId)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+				return &token, nil
+			})
+
+		token, err := s.userSvc.IssueRefreshToken(ctx, userId, model.RoleEmployee)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, hashRefreshToken(token), storedHash)
+		assert.NotEqual(t, token, storedHash)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockUserRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.userSvc.IssueRefreshToken(ctx, userId, model.RoleEmployee)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_RefreshSession(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	session := &model.RefreshToken{Id: uuid.New(), FamilyId: uuid.New(), UserId: uuid.NewString(), Role: model.RoleModerator}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		var newHash string
+		s.mockUserRepo.EXPECT().RotateRefreshToken(gomock.Any(), hashRefreshToken("old"), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, hash string, _, _ time.Time) (*model.RefreshToken, error) {
+				newHash = hash
+				return session, nil
+			})
+
+		res, token, err := s.userSvc.RefreshSession(ctx, "old")
+
+		require.NoError(t, err)
+		assert.Equal(t, session, res)
+		assert.NotEqual(t, "old", token)
+		assert.Equal(t, hashRefreshToken(token), newHash)
+	})
+	t.Run("reused token revokes family", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		gomock.InOrder(
+			s.mockUserRepo.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, customErrors.ErrInvalidRefreshToken),
+			s.mockUserRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), hashRefreshToken("old"), gomock.Any()).Return(nil),
+		)
+
+		_, _, err := s.userSvc.RefreshSession(ctx, "old")
+
+		require.ErrorIs(t, err, customErrors.ErrInvalidRefreshToken)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockUserRepo.EXPECT().RotateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, _, err := s.userSvc.RefreshSession(ctx, "old")
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_Logout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userId := uuid.NewString()
+	accessToken := model.RevokedToken{Id: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("revokes both tokens", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(nil, nil)
+		s.mockUserRepo.EXPECT().GetRefreshToken(gomock.Any(), hashRefreshToken("refresh")).Return(&model.RefreshToken{UserId: userId}, nil)
+		s.mockUserRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), hashRefreshToken("refresh"), gomock.Any()).Return(nil)
+		s.mockUserRepo.EXPECT().RevokeAccessToken(gomock.Any(), accessToken).Return(nil)
+
+		revoked, err := s.userSvc.IsRevoked(ctx, accessToken.Id)
+		require.NoError(t, err)
+		require.False(t, revoked)
+
+		require.NoError(t, s.userSvc.Logout(ctx, userId, "refresh", accessToken))
+
+		revoked, err = s.userSvc.IsRevoked(ctx, accessToken.Id)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+	t.Run("without refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().RevokeAccessToken(gomock.Any(), accessToken).Return(nil)
+
+		err := s.userSvc.Logout(ctx, userId, "", accessToken)
+
+		require.NoError(t, err)
+	})
+	t.Run("refresh token of another user", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().GetRefreshToken(gomock.Any(), hashRefreshToken("refresh")).Return(&model.RefreshToken{UserId: uuid.NewString()}, nil)
+
+		err := s.userSvc.Logout(ctx, userId, "refresh", accessToken)
+
+		require.ErrorIs(t, err, customErrors.ErrAccessDenied)
+	})
+	t.Run("unknown refresh token", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().GetRefreshToken(gomock.Any(), hashRefreshToken("refresh")).Return(nil, nil)
+		s.mockUserRepo.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), hashRefreshToken("refresh"), gomock.Any()).Return(nil)
+		s.mockUserRepo.EXPECT().RevokeAccessToken(gomock.Any(), accessToken).Return(nil)
+
+		err := s.userSvc.Logout(ctx, userId, "refresh", accessToken)
+
+		require.NoError(t, err)
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockUserRepo.EXPECT().RevokeAccessToken(gomock.Any(), gomock.Any()).Return(dbErr)
+
+		err := s.userSvc.Logout(ctx, userId, "", accessToken)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_IsRevoked(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	revokedId := uuid.NewString()
+	revokedTokens := []model.RevokedToken{{Id: revokedId, ExpiresAt: time.Now().Add(time.Hour)}}
+
+	t.Run("loads once while fresh", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(revokedTokens, nil).Times(1)
+
+		for range 3 {
+			revoked, err := s.userSvc.IsRevoked(ctx, revokedId)
+			require.NoError(t, err)
+			assert.True(t, revoked)
+		}
+		revoked, err := s.userSvc.IsRevoked(ctx, uuid.NewString())
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+	t.Run("reloads when stale", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		gomock.InOrder(
+			s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(nil, nil),
+			s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(revokedTokens, nil),
+		)
+
+		revoked, err := s.userSvc.IsRevoked(ctx, revokedId)
+		require.NoError(t, err)
+		require.False(t, revoked)
+		s.userSvc.denylist.loadedAt = time.Now().Add(-denylistRefreshInterval - time.Second)
+
+		revoked, err = s.userSvc.IsRevoked(ctx, revokedId)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+	t.Run("keeps stale copy on db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		gomock.InOrder(
+			s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(revokedTokens, nil),
+			s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error")),
+		)
+
+		_, err := s.userSvc.IsRevoked(ctx, revokedId)
+		require.NoError(t, err)
+		s.userSvc.denylist.loadedAt = time.Now().Add(-denylistRefreshInterval - time.Second)
+
+		revoked, err := s.userSvc.IsRevoked(ctx, revokedId)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+	t.Run("never loaded", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockUserRepo.EXPECT().ListRevokedAccessTokens(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.userSvc.IsRevoked(ctx, revokedId)
+
+		require.ErrorIs(t, err, dbErr)
+	})
+}
+
+func Test_TokenDenylistReload(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	revokedId := uuid.NewString()
+	revokedTokens := []model.RevokedToken{{Id: revokedId, ExpiresAt: time.Now().Add(time.Hour)}}
+
+	t.Run("stale copy served while reloading", func(t *testing.T) {
+		t.Parallel()
+
+		var d tokenDenylist
+		require.NoError(t, d.reload(ctx, time.Now(), func(context.Context, time.Time) ([]model.RevokedToken, error) {
+			return revokedTokens, nil
+		}))
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan error)
+		stale := time.Now().Add(denylistRefreshInterval + time.Second)
+		go func() {
+			done <- d.reload(ctx, stale, func(context.Context, time.Time) ([]model.RevokedToken, error) {
+				close(started)
+				<-release
+				return nil, nil
+			})
+		}()
+		<-started
+
+		require.NoError(t, d.reload(ctx, stale, func(context.Context, time.Time) ([]model.RevokedToken, error) {
+			t.Error("only one reload may query the database")
+			return nil, nil
+		}))
+		revoked, fresh := d.contains(revokedId, stale)
+		assert.True(t, revoked)
+		assert.False(t, fresh)
+
+		close(release)
+		require.NoError(t, <-done)
+		// The token is kept although the new list misses it: revocations are
+		// final until the token expires.
+		revoked, fresh = d.contains(revokedId, stale)
+		assert.True(t, revoked)
+		assert.True(t, fresh)
+	})
+	t.Run("first load is shared", func(t *testing.T) {
+		t.Parallel()
+
+		var d tokenDenylist
+		release := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- d.reload(ctx, time.Now(), func(context.Context, time.Time) ([]model.RevokedToken, error) {
+				close(started)
+				<-release
+				return revokedTokens, nil
+			})
+		}()
+		<-started
+
+		waiter := make(chan error)
+		go func() {
+			waiter <- d.reload(ctx, time.Now(), func(context.Context, time.Time) ([]model.RevokedToken, error) {
+				t.Error("only one reload may query the database")
+				return nil, nil
+			})
+		}()
+		close(release)
+
+		require.NoError(t, <-done)
+		require.NoError(t, <-waiter)
+		revoked, _ := d.contains(revokedId, time.Now())
+		assert.True(t, revoked)
+	})
+}
+
+func Test_DeleteExpiredTokens(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().DeleteExpiredTokens(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+
+		require.NoError(t, s.userSvc.DeleteExpiredTokens(ctx))
+	})
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		dbErr := errors.New("db error")
+		s.mockUserRepo.EXPECT().DeleteExpiredTokens(gomock.Any(), gomock.Any()).Return(int64(0), dbErr)
+
+		require.ErrorIs(t, s.userSvc.DeleteExpiredTokens(ctx), dbErr)
+	})
+	t.Run("runs until cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		ctx, cancel := context.WithCancel(ctx)
+		s.mockUserRepo.EXPECT().DeleteExpiredTokens(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, time.Time) (int64, error) {
+				cancel()
+				return 0, nil
+			})
+
+		s.userSvc.RunTokenCleanup(ctx, time.Millisecond)
+	})
+}
@@ -0,0 +1,75 @@
+//go:generate mockgen -source ./user.go -destination=./mocks/user.go -package=mock_service
+package service
+
+import (
+	"avito2/internal/errors"
+	"avito2/internal/logger"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"context"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserService interface {
+	Register(ctx context.Context, email, password string, role model.Role) (*model.User, error)
+	Login(ctx context.Context, email, password string) (*model.User, error)
+	IssueRefreshToken(ctx context.Context, userId string, role model.Role) (string, error)
+	RefreshSession(ctx context.Context, refreshToken string) (*model.RefreshToken, string, error)
+	Logout(ctx context.Context, userId, refreshToken string, accessToken model.RevokedToken) error
+	IsRevoked(ctx context.Context, tokenId string) (bool, error)
+}
+
+type UserSvc struct {
+	repo            repository.UserRepository
+	refreshTokenTTL time.Duration
+	denylist        *tokenDenylist
+}
+
+func NewUserService(repo repository.UserRepository, refreshTokenTTL time.Duration) *UserSvc {
+	return &UserSvc{
+		repo:            repo,
+		refreshTokenTTL: refreshTokenTTL,
+		denylist:        &tokenDenylist{},
+	}
+}
+
+func (s *UserSvc) Register(ctx context.Context, email, password string, role model.Role) (*model.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to hash password", "err", err)
+		return nil, err
+	}
+
+	user, err := s.repo.CreateUser(ctx, email, string(hash), role)
+	if err != nil {
+		if err != errors.ErrUserAlreadyExists {
+			logger.FromContext(ctx).Error("failed to create user", "err", err)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// dummyPasswordHash is compared against when the email is unknown, so that a
+// login takes as long whether the user exists or not.
+const dummyPasswordHash = "$2a$10$0JC3JvFtN5rMLympvF8rUuLTzzSKDLBGgvvvCkx1GWHHx3pVgLGgi"
+
+func (s *UserSvc) Login(ctx context.Context, email, password string) (*model.User, error) {
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to get user", "err", err)
+		return nil, err
+	}
+
+	if user == nil {
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		return nil, errors.ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, errors.ErrInvalidCredentials
+	}
+	return user, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_Register(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		email    = "employee@example.com"
+		password = "password"
+		role     = model.RoleEmployee
+		dbErr    = errors.New("db error")
+	)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().CreateUser(gomock.Any(), email, gomock.Any(), role).DoAndReturn(
+			func(_ context.Context, email, passwordHash string, role model.Role) (*model.User, error) {
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)))
+				return &model.User{Email: email, PasswordHash: passwordHash, Role: role}, nil
+			})
+
+		user, err := s.userSvc.Register(ctx, email, password, role)
+
+		require.NoError(t, err)
+		assert.Equal(t, email, user.Email)
+	})
+
+	t.Run("user already exists", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, customErrors.ErrUserAlreadyExists)
+
+		_, err := s.userSvc.Register(ctx, email, password, role)
+
+		require.EqualError(t, err, customErrors.ErrUserAlreadyExists.Error())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+
+		_, err := s.userSvc.Register(ctx, email, password, role)
+
+		require.Error(t, err)
+	})
+}
+
+func Test_Login(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		email    = "employee@example.com"
+		password = "password"
+		dbErr    = errors.New("db error")
+	)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &model.User{Email: email, PasswordHash: string(hash), Role: model.RoleEmployee}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), email).Return(user, nil)
+
+		res, err := s.userSvc.Login(ctx, email, password)
+
+		require.NoError(t, err)
+		assert.Equal(t, user, res)
+	})
+
+	t.Run("user does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), email).Return(nil, nil)
+
+		_, err := s.userSvc.Login(ctx, email, password)
+
+		require.EqualError(t, err, customErrors.ErrInvalidCredentials.Error())
+		// the dummy hash costs as much as a real one
+		cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+		require.NoError(t, err)
+		assert.Equal(t, bcrypt.DefaultCost, cost)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), email).Return(user, nil)
+
+		_, err := s.userSvc.Login(ctx, email, "wrong password")
+
+		require.EqualError(t, err, customErrors.ErrInvalidCredentials.Error())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		t.Parallel()
+
+		s := setUp(t)
+		defer s.tearDown()
+		s.mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), email).Return(nil, dbErr)
+
+		_, err := s.userSvc.Login(ctx, email, password)
+
+		require.Error(t, err)
+	})
+}
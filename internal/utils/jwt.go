@@ -3,30 +3,49 @@ package utils
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 type JWTGenerator interface {
-	GenerateJWT(role string) (string, error)
+	GenerateJWT(userId, role string) (string, error)
+	JWKS() JWKS
 }
 
-type JWTGen struct{}
+type JWTGen struct {
+	keys *KeySet
+	ttl  time.Duration
+}
+
+func NewJWTGen(keys *KeySet, ttl time.Duration) *JWTGen {
+	return &JWTGen{
+		keys: keys,
+		ttl:  ttl,
+	}
+}
 
-func (j *JWTGen) GenerateJWT(role string) (string, error) {
+func (j *JWTGen) GenerateJWT(userId, role string) (string, error) {
+	if j.keys == nil || j.keys.signingKey == nil {
+		return "", errors.New("jwt signing key is not configured")
+	}
+
+	now := time.Now()
 	claims := jwt.MapClaims{
+		"jti":  uuid.NewString(),
+		"sub":  userId,
 		"role": role,
-		"exp":  time.Now().Add(time.Hour * 24).Unix(),
+		"iat":  now.Unix(),
+		"exp":  now.Add(j.ttl).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(j.keys.signingMethod, claims)
+	token.Header["kid"] = j.keys.signingKid
 
-	jwt := os.Getenv("JWT_SECRET")
-	if jwt == "" {
-		return "", errors.New("environment variable JWT_SECRET not found")
-	}
-	jwtSecret := []byte(jwt)
+	return token.SignedString(j.keys.signingKey)
+}
 
-	return token.SignedString(jwtSecret)
+// JWKS returns the keys tokens issued by the service are verified with.
+func (j *JWTGen) JWKS() JWKS {
+	return j.keys.JWKS()
 }
@@ -2,36 +2,62 @@ package utils
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
 func Test_GenerateJWT(t *testing.T) {
 	t.Parallel()
 	var (
+		userId = "8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a"
 		role   = "employee"
-		jwtGen = JWTGen{}
 	)
 
-	t.Run("no JWT_SECRET", func(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	require.NoError(t, err)
+
+	t.Run("no key", func(t *testing.T) {
 		t.Parallel()
+		jwtGen := NewJWTGen(nil, time.Hour)
 
-		_, err := jwtGen.GenerateJWT(role)
+		_, err := jwtGen.GenerateJWT(userId, role)
 
 		require.Error(t, err)
 	})
-}
-
-func Test_GenerateJWTWithSetEnv(t *testing.T) {
-	var (
-		jwtGen = JWTGen{}
-	)
 	t.Run("success", func(t *testing.T) {
-		role := "employee"
-		t.Setenv("JWT_SECRET", "secret")
+		t.Parallel()
+		jwtGen := NewJWTGen(keys, time.Hour)
 
-		_, err := jwtGen.GenerateJWT(role)
+		tokenString, err := jwtGen.GenerateJWT(userId, role)
+		require.NoError(t, err)
 
+		claims := jwt.MapClaims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims, keys.Keyfunc, jwt.WithValidMethods(SigningMethods))
+		require.NoError(t, err)
+		require.Equal(t, "EdDSA", token.Method.Alg())
+		require.Equal(t, keys.signingKid, token.Header["kid"])
+		require.Equal(t, userId, claims["sub"])
+		require.Equal(t, role, claims["role"])
+		_, err = uuid.Parse(claims["jti"].(string))
 		require.NoError(t, err)
+		require.InDelta(t, time.Now().Add(time.Hour).Unix(), claims["exp"], 5)
+	})
+	t.Run("unique token ids", func(t *testing.T) {
+		t.Parallel()
+
+		jwtGen := NewJWTGen(keys, time.Hour)
+		ids := map[any]bool{}
+		for range 3 {
+			tokenString, err := jwtGen.GenerateJWT(userId, role)
+			require.NoError(t, err)
+			claims := jwt.MapClaims{}
+			_, _, err = jwt.NewParser().ParseUnverified(tokenString, claims)
+			require.NoError(t, err)
+			ids[claims["jti"]] = true
+		}
+		require.Len(t, ids, 3)
 	})
 }
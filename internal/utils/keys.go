@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for RS256.
+const minRSAKeyBits = 2048
+
+// SigningMethods lists the algorithms tokens may be signed with. Anything
+// else, HS256 and "none" included, is rejected before the key is looked up.
+var SigningMethods = []string{
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodEdDSA.Alg(),
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+}
+
+// KeySet holds the key new tokens are signed with and every key, identified
+// by kid, that tokens are still accepted from.
+type KeySet struct {
+	signingKid    string
+	signingKey    crypto.Signer
+	signingMethod jwt.SigningMethod
+	keys          map[string]verificationKey
+}
+
+// JWK is the public part of a verification key in RFC 7517 form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadKeySet reads every *.pem file of dir; the file name without the
+// extension is the key's kid. Private keys (PKCS#8, or PKCS#1 for RSA) can
+// sign and verify, public keys (PKIX) only verify, which is how a key is
+// published before it starts signing and kept after it stops. Tokens are
+// signed with signingKid or, if it is empty, with the last private key by
+// name.
+func LoadKeySet(dir, signingKid string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	set := &KeySet{keys: make(map[string]verificationKey, len(paths))}
+	signers := map[string]crypto.Signer{}
+	lastSigner := ""
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		signer, public, err := parseKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		method, err := signingMethodFor(public)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", kid, err)
+		}
+		set.keys[kid] = verificationKey{method: method, public: public}
+		if signer != nil {
+			signers[kid] = signer
+			lastSigner = kid
+		}
+	}
+
+	if signingKid == "" {
+		signingKid = lastSigner
+	}
+	if signingKid == "" {
+		return nil, fmt.Errorf("no private keys in %s", dir)
+	}
+	signer, ok := signers[signingKid]
+	if !ok {
+		return nil, fmt.Errorf("signing key %s not found among private keys in %s", signingKid, dir)
+	}
+	set.signingKid = signingKid
+	set.signingKey = signer
+	set.signingMethod = set.keys[signingKid].method
+	return set, nil
+}
+
+// NewEphemeralKeySet generates an Ed25519 key that lives as long as the
+// process. Tokens signed with it are not accepted by other instances or after
+// a restart, so it is meant for local development only.
+func NewEphemeralKeySet() (*KeySet, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	kid := "ephemeral-" + base64.RawURLEncoding.EncodeToString(public[:6])
+	return &KeySet{
+		signingKid:    kid,
+		signingKey:    private,
+		signingMethod: jwt.SigningMethodEdDSA,
+		keys: map[string]verificationKey{
+			kid: {method: jwt.SigningMethodEdDSA, public: public},
+		},
+	}, nil
+}
+
+// Keyfunc resolves the verification key by the token's kid and makes sure the
+// token is signed with the algorithm of that key.
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, errors.New("token has no kid")
+	}
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("key %s does not sign with %s", kid, token.Method.Alg())
+	}
+	return key.public, nil
+}
+
+// JWKS returns the public keys, ordered by kid.
+func (k *KeySet) JWKS() JWKS {
+	kids := make([]string, 0, len(k.keys))
+	for kid := range k.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	res := JWKS{Keys: make([]JWK, 0, len(kids))}
+	for _, kid := range kids {
+		key := k.keys[kid]
+		jwk := JWK{Kid: kid, Use: "sig", Alg: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		res.Keys = append(res.Keys, jwk)
+	}
+	return res
+}
+
+func parseKey(data []byte) (crypto.Signer, crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, signer.Public(), nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, key.Public(), nil
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, key, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func signingMethodFor(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("rsa key must be at least %d bits", minRSAKeyBits)
+		}
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", public)
+	}
+}
@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePrivateKey(t *testing.T, dir, kid string, key any) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	writePEM(t, dir, kid, "PRIVATE KEY", der)
+}
+
+func writePublicKey(t *testing.T, dir, kid string, key any) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	writePEM(t, dir, kid, "PUBLIC KEY", der)
+}
+
+func writePEM(t *testing.T, dir, kid, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+}
+
+func Test_LoadKeySet(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("signs with last private key", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writePrivateKey(t, dir, "2025-04", rsaKey)
+		writePrivateKey(t, dir, "2025-05", edPrivate)
+		writePublicKey(t, dir, "2025-06", otherPrivate.Public())
+
+		keys, err := LoadKeySet(dir, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-05", keys.signingKid)
+		assert.Equal(t, jwt.SigningMethodEdDSA, keys.signingMethod)
+		assert.Len(t, keys.keys, 3)
+	})
+	t.Run("explicit signing key", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writePrivateKey(t, dir, "2025-04", rsaKey)
+		writePrivateKey(t, dir, "2025-05", edPrivate)
+
+		keys, err := LoadKeySet(dir, "2025-04")
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-04", keys.signingKid)
+		assert.Equal(t, jwt.SigningMethodRS256, keys.signingMethod)
+	})
+	t.Run("signing key is public only", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writePrivateKey(t, dir, "2025-05", edPrivate)
+		writePublicKey(t, dir, "2025-06", otherPrivate.Public())
+
+		_, err := LoadKeySet(dir, "2025-06")
+
+		require.Error(t, err)
+	})
+	t.Run("no private keys", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writePublicKey(t, dir, "2025-05", edPublic)
+
+		_, err := LoadKeySet(dir, "")
+
+		require.Error(t, err)
+	})
+	t.Run("weak rsa key", func(t *testing.T) {
+		t.Parallel()
+
+		weak, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		dir := t.TempDir()
+		writePrivateKey(t, dir, "weak", weak)
+
+		_, err = LoadKeySet(dir, "")
+
+		require.ErrorContains(t, err, "2048")
+	})
+	t.Run("not a key", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.pem"), []byte("test"), 0o600))
+
+		_, err := LoadKeySet(dir, "")
+
+		require.Error(t, err)
+	})
+}
+
+func Test_KeySet(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	writePrivateKey(t, dir, "rsa", rsaKey)
+	writePrivateKey(t, dir, "ed", edPrivate)
+	keys, err := LoadKeySet(dir, "rsa")
+	require.NoError(t, err)
+
+	parse := func(token *jwt.Token, key any) error {
+		tokenString, err := token.SignedString(key)
+		require.NoError(t, err)
+		_, err = jwt.Parse(tokenString, keys.Keyfunc, jwt.WithValidMethods(SigningMethods))
+		return err
+	}
+	newToken := func(method jwt.SigningMethod, kid string) *jwt.Token {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()})
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		return token
+	}
+
+	t.Run("verifies every key", func(t *testing.T) {
+		t.Parallel()
+
+		require.NoError(t, parse(newToken(jwt.SigningMethodRS256, "rsa"), rsaKey))
+		require.NoError(t, parse(newToken(jwt.SigningMethodEdDSA, "ed"), edPrivate))
+	})
+	t.Run("unknown kid", func(t *testing.T) {
+		t.Parallel()
+
+		require.Error(t, parse(newToken(jwt.SigningMethodEdDSA, "other"), edPrivate))
+	})
+	t.Run("missing kid", func(t *testing.T) {
+		t.Parallel()
+
+		require.Error(t, parse(newToken(jwt.SigningMethodEdDSA, ""), edPrivate))
+	})
+	t.Run("algorithm of another key", func(t *testing.T) {
+		t.Parallel()
+
+		require.Error(t, parse(newToken(jwt.SigningMethodEdDSA, "rsa"), edPrivate))
+	})
+	t.Run("hmac rejected", func(t *testing.T) {
+		t.Parallel()
+
+		// HS256 keyed with the RSA public key is the classic confusion attack.
+		der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+		require.NoError(t, err)
+		require.Error(t, parse(newToken(jwt.SigningMethodHS256, "rsa"), der))
+	})
+	t.Run("none rejected", func(t *testing.T) {
+		t.Parallel()
+
+		require.Error(t, parse(newToken(jwt.SigningMethodNone, "rsa"), jwt.UnsafeAllowNoneSignatureType))
+	})
+	t.Run("jwks", func(t *testing.T) {
+		t.Parallel()
+
+		jwks := keys.JWKS()
+
+		require.Len(t, jwks.Keys, 2)
+		assert.Equal(t, JWK{Kty: "OKP", Kid: "ed", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: jwks.Keys[0].X}, jwks.Keys[0])
+		assert.NotEmpty(t, jwks.Keys[0].X)
+		assert.Equal(t, "RSA", jwks.Keys[1].Kty)
+		assert.Equal(t, "RS256", jwks.Keys[1].Alg)
+		assert.Equal(t, "AQAB", jwks.Keys[1].E)
+		assert.NotEmpty(t, jwks.Keys[1].N)
+	})
+}
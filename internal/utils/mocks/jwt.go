@@ -5,6 +5,7 @@
 package mock_jwt
 
 import (
+	utils "avito2/internal/utils"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,16 +35,30 @@ func (m *MockJWTGenerator) EXPECT() *MockJWTGeneratorMockRecorder {
 }
 
 // GenerateJWT mocks base method.
-func (m *MockJWTGenerator) GenerateJWT(role string) (string, error) {
+func (m *MockJWTGenerator) GenerateJWT(userId, role string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateJWT", role)
+	ret := m.ctrl.Call(m, "GenerateJWT", userId, role)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateJWT indicates an expected call of GenerateJWT.
-func (mr *MockJWTGeneratorMockRecorder) GenerateJWT(role interface{}) *gomock.Call {
+func (mr *MockJWTGeneratorMockRecorder) GenerateJWT(userId, role interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJWT", reflect.TypeOf((*MockJWTGenerator)(nil).GenerateJWT), role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJWT", reflect.TypeOf((*MockJWTGenerator)(nil).GenerateJWT), userId, role)
+}
+
+// JWKS mocks base method.
+func (m *MockJWTGenerator) JWKS() utils.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(utils.JWKS)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockJWTGeneratorMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockJWTGenerator)(nil).JWKS))
 }
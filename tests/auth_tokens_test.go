@@ -0,0 +1,110 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RefreshTokenRotation(t *testing.T) {
+	database.SetUp(t, "refresh_tokens", "revoked_tokens")
+	ctx := context.Background()
+	userSvc := service.NewUserService(repository.NewUserRepository(database.DB), time.Hour)
+	userId := uuid.NewString()
+
+	first, err := userSvc.IssueRefreshToken(ctx, userId, model.RoleEmployee)
+	require.NoError(t, err)
+
+	session, second, err := userSvc.RefreshSession(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, userId, session.UserId)
+	assert.Equal(t, model.RoleEmployee, session.Role)
+
+	// The first token was already exchanged: presenting it again revokes the
+	// token that replaced it too.
+	_, _, err = userSvc.RefreshSession(ctx, first)
+	require.ErrorIs(t, err, customErrors.ErrInvalidRefreshToken)
+	_, _, err = userSvc.RefreshSession(ctx, second)
+	require.ErrorIs(t, err, customErrors.ErrInvalidRefreshToken)
+}
+
+func Test_Logout(t *testing.T) {
+	database.SetUp(t, "refresh_tokens", "revoked_tokens")
+	ctx := context.Background()
+	userSvc := service.NewUserService(repository.NewUserRepository(database.DB), time.Hour)
+	accessToken := model.RevokedToken{Id: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	userId := uuid.NewString()
+	refreshToken, err := userSvc.IssueRefreshToken(ctx, userId, model.RoleModerator)
+	require.NoError(t, err)
+
+	// Someone else holding the refresh token cannot end the session.
+	err = userSvc.Logout(ctx, uuid.NewString(), refreshToken, model.RevokedToken{Id: uuid.NewString(), ExpiresAt: time.Now().Add(time.Hour)})
+	require.ErrorIs(t, err, customErrors.ErrAccessDenied)
+
+	require.NoError(t, userSvc.Logout(ctx, userId, refreshToken, accessToken))
+
+	_, _, err = userSvc.RefreshSession(ctx, refreshToken)
+	require.ErrorIs(t, err, customErrors.ErrInvalidRefreshToken)
+
+	// A fresh instance sees the revocation through the database.
+	other := service.NewUserService(repository.NewUserRepository(database.DB), time.Hour)
+	revoked, err := other.IsRevoked(ctx, accessToken.Id)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func Test_DeleteExpiredTokens(t *testing.T) {
+	database.SetUp(t, "refresh_tokens", "revoked_tokens")
+	ctx := context.Background()
+	userRepo := repository.NewUserRepository(database.DB)
+	now := time.Now()
+
+	_, err := userRepo.CreateRefreshToken(ctx, "expired", model.RefreshToken{FamilyId: uuid.New(), UserId: uuid.NewString(), Role: model.RoleEmployee, ExpiresAt: now.Add(-time.Minute), CreatedAt: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	_, err = userRepo.CreateRefreshToken(ctx, "live", model.RefreshToken{FamilyId: uuid.New(), UserId: uuid.NewString(), Role: model.RoleEmployee, ExpiresAt: now.Add(time.Hour), CreatedAt: now})
+	require.NoError(t, err)
+	live := model.RevokedToken{Id: uuid.NewString(), ExpiresAt: now.Add(time.Hour)}
+	require.NoError(t, userRepo.RevokeAccessToken(ctx, model.RevokedToken{Id: uuid.NewString(), ExpiresAt: now.Add(-time.Minute)}))
+	require.NoError(t, userRepo.RevokeAccessToken(ctx, live))
+
+	deleted, err := userRepo.DeleteExpiredTokens(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	_, err = userRepo.RotateRefreshToken(ctx, "live", "next", now, now.Add(time.Hour))
+	require.NoError(t, err)
+	revoked, err := userRepo.ListRevokedAccessTokens(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, revoked, 1)
+	assert.Equal(t, live.Id, revoked[0].Id)
+}
+
+func Test_RevokedTokenExpiresAtTimeZone(t *testing.T) {
+	database.SetUp(t, "refresh_tokens", "revoked_tokens")
+	ctx := context.Background()
+	userRepo := repository.NewUserRepository(database.DB)
+
+	// A host behind UTC: the expiry must come back as the same instant, not the
+	// same wall-clock time.
+	behindUtc := time.FixedZone("UTC-5", -5*60*60)
+	token := model.RevokedToken{Id: uuid.NewString(), ExpiresAt: time.Now().Add(time.Minute).In(behindUtc)}
+	require.NoError(t, userRepo.RevokeAccessToken(ctx, token))
+
+	revoked, err := userRepo.ListRevokedAccessTokens(ctx, time.Now())
+	require.NoError(t, err)
+	require.Len(t, revoked, 1)
+	assert.WithinDuration(t, token.ExpiresAt, revoked[0].ExpiresAt, time.Millisecond)
+
+	isRevoked, err := service.NewUserService(userRepo, time.Hour).IsRevoked(ctx, token.Id)
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Catalog(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions", "catalog_items")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+
+	item := model.CatalogItem{Barcode: "4601234567893", Sku: "SHOE-42", Name: "Кеды", Type: model.ProductTypeShoes, WeightGrams: 800, LengthMm: 320, WidthMm: 200, HeightMm: 120}
+	_, err := svc.CreateCatalogItem(ctx, item)
+	require.NoError(t, err)
+	_, err = svc.CreateCatalogItem(ctx, item)
+	require.ErrorIs(t, err, customErrors.ErrCatalogItemAlreadyExists)
+
+	bySku, err := svc.GetCatalogItem(ctx, "SHOE-42")
+	require.NoError(t, err)
+	assert.Equal(t, item.Barcode, bySku.Barcode)
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityKazan})
+	require.NoError(t, err)
+	reception, err := svc.CreateReception(ctx, pvz.Id, model.Actor{Id: "8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a", Role: model.RoleEmployee})
+	require.NoError(t, err)
+
+	product, err := svc.AddProduct(ctx, pvz.Id, "", item.Barcode)
+	require.NoError(t, err)
+	assert.Equal(t, model.ProductTypeShoes, product.Type)
+	assert.Equal(t, item.Barcode, product.Barcode)
+
+	_, err = svc.AddProduct(ctx, pvz.Id, "", "4600000000000")
+	require.ErrorIs(t, err, customErrors.ErrCatalogItemDoesNotExist)
+
+	// The barcode of one item can be the SKU of another.
+	other := model.CatalogItem{Barcode: "4607654321092", Sku: item.Barcode, Name: "Футболка", Type: model.ProductTypeClothes, WeightGrams: 200, LengthMm: 300, WidthMm: 200, HeightMm: 20}
+	_, err = svc.CreateCatalogItem(ctx, other)
+	require.NoError(t, err)
+	onlySku := model.CatalogItem{Barcode: "4607654321108", Sku: "4600000000015", Name: "Наушники", Type: model.ProductTypeElectronics, WeightGrams: 100, LengthMm: 100, WidthMm: 100, HeightMm: 50}
+	_, err = svc.CreateCatalogItem(ctx, onlySku)
+	require.NoError(t, err)
+
+	byCode, err := svc.GetCatalogItem(ctx, item.Barcode)
+	require.NoError(t, err)
+	assert.Equal(t, item.Barcode, byCode.Barcode)
+	product, err = svc.AddProduct(ctx, pvz.Id, "", item.Barcode)
+	require.NoError(t, err)
+	assert.Equal(t, model.ProductTypeShoes, product.Type)
+
+	require.True(t, model.IsValidBarcode(onlySku.Sku))
+	_, err = svc.AddProduct(ctx, pvz.Id, "", onlySku.Sku)
+	require.ErrorIs(t, err, customErrors.ErrCatalogItemDoesNotExist)
+
+	_, err = svc.AddProducts(ctx, pvz.Id, []model.ProductBatchItem{{Type: model.ProductTypeElectronics, Barcode: item.Barcode}})
+	require.ErrorIs(t, err, customErrors.ErrInvalidProductBatch)
+	batch, err := svc.AddProducts(ctx, pvz.Id, []model.ProductBatchItem{{Type: model.ProductTypeShoes, Barcode: item.Barcode}})
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+
+	locations, err := svc.FindProductsByBarcode(ctx, item.Barcode)
+	require.NoError(t, err)
+	require.Len(t, locations, 3)
+	assert.Equal(t, batch[0].Id, locations[0].ProductId)
+	assert.Equal(t, product.Id, locations[1].ProductId)
+	assert.Equal(t, reception.Id, locations[0].ReceptionId)
+	assert.Equal(t, pvz.Id, locations[0].PvzId)
+	assert.Equal(t, model.CityKazan, locations[0].City)
+
+	barcodeOnly, err := svc.AddProducts(ctx, pvz.Id, []model.ProductBatchItem{{Barcode: item.Barcode}, {Barcode: other.Barcode}, {Type: model.ProductTypeClothes}})
+	require.NoError(t, err)
+	require.Len(t, barcodeOnly, 3)
+	assert.Equal(t, model.ProductTypeShoes, barcodeOnly[0].Type)
+	assert.Equal(t, model.ProductTypeClothes, barcodeOnly[1].Type)
+	assert.Equal(t, other.Barcode, barcodeOnly[1].Barcode)
+}
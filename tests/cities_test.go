@@ -0,0 +1,58 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cities(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+
+	cities, err := svc.ListCities(ctx)
+	require.NoError(t, err)
+	names := []model.City{}
+	for _, city := range cities {
+		names = append(names, city.Name)
+	}
+	assert.Subset(t, names, []model.City{model.CityMoscow, model.CityKazan, model.CitySaintPetersburg})
+
+	const novosibirsk = model.City("Новосибирск")
+	t.Cleanup(func() {
+		database.SetUp(t, "pvz")
+		_ = repo.DeleteCity(ctx, novosibirsk)
+	})
+
+	_, err = svc.CreatePvz(ctx, model.CreatePvzRequest{City: novosibirsk})
+	require.ErrorIs(t, err, customErrors.ErrInvalidCity)
+
+	city, err := svc.CreateCity(ctx, model.CityInfo{Name: novosibirsk, Region: "Новосибирская область", Timezone: "Asia/Novosibirsk", Active: true})
+	require.NoError(t, err)
+	assert.True(t, city.Active)
+	_, err = svc.CreateCity(ctx, *city)
+	require.ErrorIs(t, err, customErrors.ErrCityAlreadyExists)
+
+	pvz, err := svc.CreatePvz(ctx, model.CreatePvzRequest{City: novosibirsk})
+	require.NoError(t, err)
+	assert.Equal(t, novosibirsk, pvz.City)
+
+	require.ErrorIs(t, svc.DeleteCity(ctx, novosibirsk), customErrors.ErrCityInUse)
+
+	inactive := false
+	city, err = svc.UpdateCity(ctx, novosibirsk, model.UpdateCityRequest{Active: &inactive})
+	require.NoError(t, err)
+	assert.False(t, city.Active)
+	assert.Equal(t, "Asia/Novosibirsk", city.Timezone)
+
+	_, err = svc.CreatePvz(ctx, model.CreatePvzRequest{City: novosibirsk})
+	require.ErrorIs(t, err, customErrors.ErrCityInactive)
+}
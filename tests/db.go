@@ -11,12 +11,24 @@ type TDB struct {
 	DB db.DBops
 }
 
+// NewFromEnv applies the migrations embedded into the service binary and
+// connects to the test database.
 func NewFromEnv() *TDB {
-	db, err := db.NewDb(context.Background())
+	ctx := context.Background()
+	migrator, err := db.NewMigrator(cfg.Database)
 	if err != nil {
 		panic(err)
 	}
-	return &TDB{DB: db}
+	defer migrator.Close()
+	if _, err := migrator.Up(ctx); err != nil {
+		panic(err)
+	}
+
+	database, err := db.NewDb(ctx, cfg.Database)
+	if err != nil {
+		panic(err)
+	}
+	return &TDB{DB: database}
 }
 
 func (d *TDB) SetUp(t *testing.T, tableName ...string) {
@@ -30,8 +30,10 @@ func Test_ReceptionPipline(t *testing.T) {
 		database.SetUp(t, "pvz", "products", "receptions")
 		repo := repository.NewRepository(database.DB)
 		svc := service.NewService(repo)
-		jwrGen := &utils.JWTGen{}
-		hm := handler_manager.NewHandlerManager(svc, jwrGen)
+		userSvc := service.NewUserService(repository.NewUserRepository(database.DB), cfg.Auth.RefreshTokenTTL)
+		jwrGen := utils.NewJWTGen(keys, cfg.Auth.TokenTTL)
+		auth := middleware.NewAuthMiddleware(keys, userSvc)
+		hm := handler_manager.NewHandlerManager(svc, userSvc, jwrGen)
 
 		body, err := json.Marshal(moderatorDummyLoginRequest)
 		require.NoError(t, err)
@@ -72,7 +74,7 @@ func Test_ReceptionPipline(t *testing.T) {
 		req.Header.Set("Authorization", "Bearer "+moderatorToken)
 		rec = httptest.NewRecorder()
 
-		handler := middleware.AuthMiddleware(http.HandlerFunc(hm.Pvz))
+		handler := auth.Handle(http.HandlerFunc(hm.Pvz))
 		handler.ServeHTTP(rec, req)
 		assert.Equal(t, http.StatusCreated, rec.Code)
 
@@ -87,7 +89,7 @@ func Test_ReceptionPipline(t *testing.T) {
 		req.Header.Set("Authorization", "Bearer "+employeeToken)
 		rec = httptest.NewRecorder()
 
-		handler = middleware.AuthMiddleware(http.HandlerFunc(hm.CreateReception))
+		handler = auth.Handle(http.HandlerFunc(hm.CreateReception))
 		handler.ServeHTTP(rec, req)
 		assert.Equal(t, http.StatusCreated, rec.Code)
 
@@ -99,13 +101,13 @@ func Test_ReceptionPipline(t *testing.T) {
 			req.Header.Set("Authorization", "Bearer "+employeeToken)
 			rec = httptest.NewRecorder()
 
-			handler = middleware.AuthMiddleware(http.HandlerFunc(hm.AddProduct))
+			handler = auth.Handle(http.HandlerFunc(hm.AddProduct))
 			handler.ServeHTTP(rec, req)
 			assert.Equal(t, http.StatusCreated, rec.Code)
 		}
 
 		router := mux.NewRouter()
-		router.Handle("/pvz/{pvzId}/close_last_reception", middleware.AuthMiddleware(http.HandlerFunc(hm.CloseLastReception)))
+		router.Handle("/pvz/{pvzId}/close_last_reception", auth.Handle(http.HandlerFunc(hm.CloseLastReception)))
 
 		req = httptest.NewRequest(http.MethodPost, "/pvz/"+pvz.Id.String()+"/close_last_reception", nil)
 		req.Header.Set("Authorization", "Bearer "+employeeToken)
@@ -115,3 +117,81 @@ func Test_ReceptionPipline(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	})
 }
+
+func Test_UserRegistration(t *testing.T) {
+	registerRequest := model.RegisterRequest{
+		Email:    "employee@example.com",
+		Password: "password",
+		Role:     model.RoleEmployee,
+	}
+	loginRequest := model.LoginRequest{
+		Email:    registerRequest.Email,
+		Password: registerRequest.Password,
+	}
+
+	t.Run("register and login", func(t *testing.T) {
+		database.SetUp(t, "users")
+		repo := repository.NewRepository(database.DB)
+		svc := service.NewService(repo)
+		userSvc := service.NewUserService(repository.NewUserRepository(database.DB), cfg.Auth.RefreshTokenTTL)
+		jwrGen := utils.NewJWTGen(keys, cfg.Auth.TokenTTL)
+		auth := middleware.NewAuthMiddleware(keys, userSvc)
+		hm := handler_manager.NewHandlerManager(svc, userSvc, jwrGen)
+
+		body, err := json.Marshal(registerRequest)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+
+		hm.Register(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var user model.User
+		err = json.Unmarshal(rec.Body.Bytes(), &user)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(body))
+		rec = httptest.NewRecorder()
+
+		hm.Register(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		body, err = json.Marshal(loginRequest)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec = httptest.NewRecorder()
+
+		hm.Login(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := map[string]string{}
+		err = json.Unmarshal(rec.Body.Bytes(), &res)
+		require.NoError(t, err)
+
+		token, ok := res["token"]
+		assert.True(t, ok)
+
+		req = httptest.NewRequest(http.MethodGet, "/pvz", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec = httptest.NewRecorder()
+
+		handler := auth.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, user.Id.String(), r.Context().Value(middleware.UserId))
+			w.WriteHeader(http.StatusOK)
+		}))
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		loginRequest.Password = "wrong password"
+		body, err = json.Marshal(loginRequest)
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+		rec = httptest.NewRecorder()
+
+		hm.Login(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
@@ -1,16 +1,33 @@
 package tests
 
 import (
+	"avito2/internal/config"
+	"avito2/internal/utils"
 	"log"
 
 	"github.com/joho/godotenv"
 )
 
-var database *TDB
+var (
+	cfg      *config.Config
+	database *TDB
+	// keys signs and verifies the tokens of the handler tests.
+	keys *utils.KeySet
+)
 
 func init() {
 	if err := godotenv.Load("test.env"); err != nil {
 		log.Print("No .env file found")
 	}
+
+	var err error
+	cfg, err = config.Load("")
+	if err != nil {
+		panic(err)
+	}
 	database = NewFromEnv()
+	keys, err = utils.NewEphemeralKeySet()
+	if err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"avito2/internal/db"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaSnapshot describes the public schema: columns, indexes, constraints and
+// installed extensions, each as a sorted list of strings.
+func schemaSnapshot(t *testing.T) []string {
+	t.Helper()
+	ctx := context.Background()
+	rows, err := database.DB.ExecQuery(ctx, `
+		SELECT 'column ' || table_name || '.' || column_name || ' ' || data_type || ' ' || is_nullable || ' ' || coalesce(column_default, '')
+		FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name <> 'goose_db_version'
+		UNION ALL
+		SELECT 'index ' || indexdef
+		FROM pg_indexes
+		WHERE schemaname = 'public' AND tablename <> 'goose_db_version'
+		UNION ALL
+		SELECT 'constraint ' || c.conrelid::regclass || ' ' || c.conname || ' ' || pg_get_constraintdef(c.oid)
+		FROM pg_constraint c JOIN pg_namespace n ON n.oid = c.connamespace
+		WHERE n.nspname = 'public' AND c.conrelid::regclass::text <> 'goose_db_version'
+		UNION ALL
+		SELECT 'extension ' || extname FROM pg_extension WHERE extname <> 'plpgsql'
+		ORDER BY 1`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	snapshot := []string{}
+	for rows.Next() {
+		var item string
+		require.NoError(t, rows.Scan(&item))
+		snapshot = append(snapshot, item)
+	}
+	require.NoError(t, rows.Err())
+	return snapshot
+}
+
+func Test_Migrations(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := db.NewMigrator(cfg.Database)
+	require.NoError(t, err)
+	defer migrator.Close()
+
+	// leave the database fully migrated for the rest of the suite
+	defer func() {
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+	}()
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	migrated := schemaSnapshot(t)
+
+	t.Run("down removes everything", func(t *testing.T) {
+		_, err := migrator.Reset(ctx)
+		require.NoError(t, err)
+
+		assert.Empty(t, schemaSnapshot(t))
+	})
+	t.Run("every migration is reversible", func(t *testing.T) {
+		_, err := migrator.Reset(ctx)
+		require.NoError(t, err)
+
+		for {
+			before := schemaSnapshot(t)
+			res, err := migrator.UpByOne(ctx)
+			if errors.Is(err, goose.ErrNoNextVersion) {
+				break
+			}
+			require.NoError(t, err)
+			after := schemaSnapshot(t)
+
+			_, err = migrator.Down(ctx)
+			require.NoError(t, err, "down %s", res.Source.Path)
+			assert.Equal(t, before, schemaSnapshot(t), "down %s", res.Source.Path)
+
+			_, err = migrator.UpByOne(ctx)
+			require.NoError(t, err, "up again %s", res.Source.Path)
+			assert.Equal(t, after, schemaSnapshot(t), "up again %s", res.Source.Path)
+		}
+	})
+	t.Run("up down up yields the same schema", func(t *testing.T) {
+		_, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		_, err = migrator.Reset(ctx)
+		require.NoError(t, err)
+		_, err = migrator.Up(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, migrated, schemaSnapshot(t))
+	})
+}
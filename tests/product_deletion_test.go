@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"avito2/internal/handler_manager"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"avito2/internal/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeleteProduct(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions", "product_deletions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+	userSvc := service.NewUserService(repository.NewUserRepository(database.DB), cfg.Auth.RefreshTokenTTL)
+	jwtGen := utils.NewJWTGen(keys, cfg.Auth.TokenTTL)
+	auth := middleware.NewAuthMiddleware(keys, userSvc)
+	hm := handler_manager.NewHandlerManager(svc, userSvc, jwtGen)
+
+	router := mux.NewRouter()
+	router.Handle("/receptions", auth.Handle(http.HandlerFunc(hm.CreateReception)))
+	router.Handle("/products", auth.Handle(http.HandlerFunc(hm.AddProduct)))
+	router.Handle("/receptions/{receptionId}/products/{productId}", auth.Handle(http.HandlerFunc(hm.DeleteProduct)))
+	router.Handle("/pvz/{pvzId}/close_last_reception", auth.Handle(http.HandlerFunc(hm.CloseLastReception)))
+
+	employeeId := uuid.NewString()
+	token, err := jwtGen.GenerateJWT(employeeId, string(model.RoleEmployee))
+	require.NoError(t, err)
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+
+	do := func(method, path string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var data []byte
+		if body != nil {
+			var err error
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, path, bytes.NewReader(data))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/receptions", model.CreateReceptionRequest{PvzId: pvz.Id.String()})
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var reception model.Reception
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reception))
+
+	products := make([]model.Product, 3)
+	for i := range products {
+		rec = do(http.MethodPost, "/products", model.AddProductRequest{Type: model.ProductTypeShoes, PvzId: pvz.Id.String()})
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &products[i]))
+	}
+
+	path := "/receptions/" + reception.Id.String() + "/products/"
+	rec = do(http.MethodDelete, path+products[0].Id.String(), nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do(http.MethodDelete, path+products[0].Id.String(), nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.Equal(t, 2, countRows(t, "products"))
+
+	var deletion model.ProductDeletion
+	err = database.DB.ExecQueryRow(ctx, `SELECT product_id, product_type, reception_id, actor_id, actor_role FROM product_deletions`).
+		Scan(&deletion.ProductId, &deletion.ProductType, &deletion.ReceptionId, &deletion.ActorId, &deletion.ActorRole)
+	require.NoError(t, err)
+	assert.Equal(t, products[0].Id, deletion.ProductId)
+	assert.Equal(t, model.ProductTypeShoes, deletion.ProductType)
+	assert.Equal(t, reception.Id, deletion.ReceptionId)
+	assert.Equal(t, employeeId, deletion.ActorId)
+	assert.Equal(t, model.RoleEmployee, deletion.ActorRole)
+
+	rec = do(http.MethodPost, "/pvz/"+pvz.Id.String()+"/close_last_reception", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = do(http.MethodDelete, path+products[1].Id.String(), nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, 2, countRows(t, "products"))
+}
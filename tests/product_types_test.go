@@ -0,0 +1,75 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProductTypes(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions", "catalog_items")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+
+	types, err := svc.ListProductTypes(ctx)
+	require.NoError(t, err)
+	names := []model.ProductType{}
+	for _, pt := range types {
+		names = append(names, pt.Name)
+	}
+	assert.Subset(t, names, []model.ProductType{model.ProductTypeElectronics, model.ProductTypeClothes, model.ProductTypeShoes})
+
+	const books, magazines = model.ProductType("книги"), model.ProductType("журналы")
+	t.Cleanup(func() {
+		database.SetUp(t, "products")
+		_ = repo.DeleteProductType(ctx, books)
+		_ = repo.DeleteProductType(ctx, magazines)
+	})
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+	_, err = svc.CreateReception(ctx, pvz.Id, model.Actor{Id: "8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a", Role: model.RoleEmployee})
+	require.NoError(t, err)
+
+	_, err = svc.AddProduct(ctx, pvz.Id, books, "")
+	require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+
+	_, err = svc.CreateProductType(ctx, books)
+	require.NoError(t, err)
+	_, err = svc.CreateProductType(ctx, books)
+	require.ErrorIs(t, err, customErrors.ErrProductTypeAlreadyExists)
+
+	product, err := svc.AddProduct(ctx, pvz.Id, books, "")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, svc.DeleteProductType(ctx, books), customErrors.ErrProductTypeInUse)
+
+	_, err = svc.RenameProductType(ctx, books, magazines)
+	require.NoError(t, err)
+
+	var productType model.ProductType
+	err = database.DB.ExecQueryRow(ctx, "SELECT type FROM products WHERE id = $1", product.Id).Scan(&productType)
+	require.NoError(t, err)
+	assert.Equal(t, magazines, productType)
+
+	// Another instance deletes a type this instance still has cached: the
+	// insert fails on the foreign key and is reported as an invalid type.
+	_, err = svc.CreateProductType(ctx, magazines)
+	require.NoError(t, err)
+	_, err = svc.ListProductTypes(ctx)
+	require.NoError(t, err)
+	other := service.NewService(repository.NewRepository(database.DB))
+	require.NoError(t, other.DeleteProductType(ctx, magazines))
+
+	_, err = svc.AddProduct(ctx, pvz.Id, magazines, "")
+	require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+	_, err = svc.AddProducts(ctx, pvz.Id, []model.ProductBatchItem{{Type: magazines}})
+	require.ErrorIs(t, err, customErrors.ErrInvalidProductType)
+}
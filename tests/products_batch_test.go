@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddProducts(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+
+	items := []model.ProductBatchItem{
+		{Type: model.ProductTypeShoes},
+		{Type: model.ProductTypeClothes, Barcode: "4601234567893"},
+		{Type: model.ProductTypeElectronics},
+	}
+	var products []model.Product
+	var last *model.Product
+	err = repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+		reception, err := repo.CreateReception(ctx, tx, pvz.Id)
+		if err != nil {
+			return err
+		}
+		if products, err = repo.AddProducts(ctx, tx, reception.Id, items); err != nil {
+			return err
+		}
+		last, err = repo.DeleteLastProduct(ctx, tx, reception.Id)
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, products, len(items))
+	for i, item := range items {
+		assert.Equal(t, item.Type, products[i].Type)
+		assert.Equal(t, item.Barcode, products[i].Barcode)
+	}
+	assert.True(t, products[0].DateTime.Before(products[1].DateTime))
+	assert.Equal(t, products[2].Id, last.Id)
+	assert.Equal(t, 2, countRows(t, "products"))
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PvzDeactivation(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+	employee := model.Actor{Id: uuid.NewString(), Role: model.RoleEmployee}
+
+	pvz, err := svc.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+	assert.True(t, pvz.Active)
+
+	_, err = svc.CreateReception(ctx, pvz.Id, employee)
+	require.NoError(t, err)
+	_, err = svc.AddProduct(ctx, pvz.Id, model.ProductTypeShoes, "")
+	require.NoError(t, err)
+
+	inactive := false
+	deactivated, err := svc.UpdatePvz(ctx, pvz.Id, model.UpdatePvzRequest{Active: &inactive})
+	require.NoError(t, err)
+	assert.False(t, deactivated.Active)
+	require.NotNil(t, deactivated.DeactivatedAt)
+
+	_, err = svc.AddProduct(ctx, pvz.Id, model.ProductTypeShoes, "")
+	require.ErrorIs(t, err, customErrors.ErrPvzInactive)
+
+	closed, err := svc.CloseLastReception(ctx, pvz.Id, employee)
+	require.NoError(t, err)
+	_, err = svc.ReopenReception(ctx, closed.Id, model.Actor{Id: uuid.NewString(), Role: model.RoleModerator})
+	require.ErrorIs(t, err, customErrors.ErrPvzInactive)
+
+	_, err = svc.CreateReception(ctx, pvz.Id, employee)
+	require.ErrorIs(t, err, customErrors.ErrPvzInactive)
+
+	got, err := svc.GetPvz(ctx, pvz.Id)
+	require.NoError(t, err)
+	assert.False(t, got.Active)
+	assert.Equal(t, 1, countRows(t, "receptions"))
+	assert.Equal(t, 1, countRows(t, "products"))
+
+	active := true
+	reactivated, err := svc.UpdatePvz(ctx, pvz.Id, model.UpdatePvzRequest{Active: &active})
+	require.NoError(t, err)
+	assert.True(t, reactivated.Active)
+	assert.Nil(t, reactivated.DeactivatedAt)
+
+	_, err = svc.CreateReception(ctx, pvz.Id, employee)
+	require.NoError(t, err)
+}
@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	benchPvzCount                   = 10
+	benchReceptionsPerPvz           = 3
+	benchProductsPerReception       = 20
+	benchPageLimit            int32 = 30
+)
+
+func seedPvzInfo(b *testing.B) {
+	b.Helper()
+	ctx := context.Background()
+	database.truncateTable(ctx, "pvz", "receptions", "products")
+
+	now := time.Now()
+	for i := range benchPvzCount {
+		var pvzId uuid.UUID
+		err := database.DB.ExecQueryRow(ctx, "INSERT INTO pvz (registration_date, city) VALUES ($1, $2) RETURNING id",
+			now, model.CityMoscow).Scan(&pvzId)
+		require.NoError(b, err)
+
+		for j := range benchReceptionsPerPvz {
+			var receptionId uuid.UUID
+			dateTime := now.Add(-time.Duration(i*benchReceptionsPerPvz+j) * time.Minute)
+			err := database.DB.ExecQueryRow(ctx, "INSERT INTO receptions (date_time, pvz_id, status) VALUES ($1, $2, $3) RETURNING id",
+				dateTime, pvzId, model.ReceptionStatusClose).Scan(&receptionId)
+			require.NoError(b, err)
+
+			_, err = database.DB.Exec(ctx, `INSERT INTO products (date_time, type, reception_id)
+				SELECT $1::timestamp, $2::varchar, $3::uuid FROM generate_series(1, $4::int)`, dateTime, model.ProductTypeClothes, receptionId, benchProductsPerReception)
+			require.NoError(b, err)
+		}
+	}
+}
+
+// getPvzInfoNPlusOne reproduces the previous GetPvzInfo access pattern: one
+// query for the receptions page, then one query for products and one for the
+// pvz of every reception, each taking row locks.
+func getPvzInfoNPlusOne(ctx context.Context, tx pgx.Tx, startDate, endDate time.Time, offset, limit int32) ([]model.PvzInfo, error) {
+	rows, err := tx.Query(ctx, "SELECT id, date_time, pvz_id, status FROM receptions WHERE date_time BETWEEN $1 AND $2 ORDER BY date_time DESC LIMIT $3 OFFSET $4 FOR UPDATE",
+		startDate, endDate, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	receptions := []model.Reception{}
+	for rows.Next() {
+		var reception model.Reception
+		if err := rows.Scan(&reception.Id, &reception.DateTime, &reception.PvzId, &reception.Status); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		receptions = append(receptions, reception)
+	}
+	rows.Close()
+
+	pvzMap := map[uuid.UUID]*model.PvzInfo{}
+	for _, reception := range receptions {
+		rows, err := tx.Query(ctx, "SELECT id, date_time, type, reception_id FROM products WHERE reception_id = $1 FOR UPDATE", reception.Id)
+		if err != nil {
+			return nil, err
+		}
+		products := []model.Product{}
+		for rows.Next() {
+			var product model.Product
+			if err := rows.Scan(&product.Id, &product.DateTime, &product.Type, &product.ReceptionId); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			products = append(products, product)
+		}
+		rows.Close()
+
+		pvzInfo, ok := pvzMap[reception.PvzId]
+		if !ok {
+			var pvz model.Pvz
+			err := tx.QueryRow(ctx, "SELECT id, registration_date, city FROM pvz WHERE id = $1 FOR UPDATE", reception.PvzId).
+				Scan(&pvz.Id, &pvz.RegistrationDate, &pvz.City)
+			if err != nil {
+				return nil, err
+			}
+			pvzInfo = &model.PvzInfo{Pvz: pvz}
+			pvzMap[reception.PvzId] = pvzInfo
+		}
+		pvzInfo.Receptions = append(pvzInfo.Receptions, model.ReceptionInfo{Reception: reception, Products: products})
+	}
+
+	res := make([]model.PvzInfo, 0, len(pvzMap))
+	for _, v := range pvzMap {
+		res = append(res, *v)
+	}
+	return res, nil
+}
+
+func Benchmark_GetPvzInfo(b *testing.B) {
+	seedPvzInfo(b)
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	startDate := time.Time{}
+	endDate := time.Now().Add(time.Hour)
+
+	b.Run("n+1 queries", func(b *testing.B) {
+		for b.Loop() {
+			tx, err := database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+			require.NoError(b, err)
+
+			_, err = getPvzInfoNPlusOne(ctx, tx, startDate, endDate, 0, benchPageLimit)
+			require.NoError(b, err)
+			require.NoError(b, tx.Commit(ctx))
+		}
+	})
+	b.Run("batched queries", func(b *testing.B) {
+		for b.Loop() {
+			tx, err := database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+			require.NoError(b, err)
+
+			_, err = repo.GetPvzInfoForPeriod(ctx, tx, model.PvzInfoFilter{
+				StartDate: startDate,
+				EndDate:   endDate,
+				Page:      1,
+				Limit:     benchPageLimit,
+			})
+			require.NoError(b, err)
+			require.NoError(b, tx.Commit(ctx))
+		}
+	})
+}
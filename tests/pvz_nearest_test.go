@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindNearestPvz(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+
+	create := func(city model.City, lat, lon float64) *model.Pvz {
+		t.Helper()
+		pvz, err := svc.CreatePvz(ctx, model.CreatePvzRequest{City: city, Latitude: &lat, Longitude: &lon, WorkingHours: "09:00-21:00"})
+		require.NoError(t, err)
+		return pvz
+	}
+
+	tverskaya := create(model.CityMoscow, 55.7646, 37.6055)
+	arbat := create(model.CityMoscow, 55.7494, 37.5912)
+	closed := create(model.CityMoscow, 55.7520, 37.6175)
+	create(model.CityKazan, 55.7961, 49.1064)
+	_, err := svc.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+
+	inactive := false
+	_, err = svc.UpdatePvz(ctx, closed.Id, model.UpdatePvzRequest{Active: &inactive})
+	require.NoError(t, err)
+
+	res, err := svc.FindNearestPvz(ctx, model.NearestPvzQuery{Latitude: 55.7539, Longitude: 37.6208, RadiusMeters: 5000, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, tverskaya.Id, res[0].Id)
+	assert.Equal(t, arbat.Id, res[1].Id)
+	assert.InDelta(t, 1600, res[0].DistanceMeters, 200)
+	assert.Less(t, res[0].DistanceMeters, res[1].DistanceMeters)
+
+	res, err = svc.FindNearestPvz(ctx, model.NearestPvzQuery{Latitude: 55.7539, Longitude: 37.6208, RadiusMeters: 1000, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
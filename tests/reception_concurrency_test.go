@@ -0,0 +1,108 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/handler_manager"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"avito2/internal/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateReceptionParallelRequests(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+	userSvc := service.NewUserService(repository.NewUserRepository(database.DB), cfg.Auth.RefreshTokenTTL)
+	jwtGen := utils.NewJWTGen(keys, cfg.Auth.TokenTTL)
+	auth := middleware.NewAuthMiddleware(keys, userSvc)
+	hm := handler_manager.NewHandlerManager(svc, userSvc, jwtGen)
+	handler := auth.Handle(http.HandlerFunc(hm.CreateReception))
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+	token, err := jwtGen.GenerateJWT("8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a", string(model.RoleEmployee))
+	require.NoError(t, err)
+	body, err := json.Marshal(model.CreateReceptionRequest{PvzId: pvz.Id.String()})
+	require.NoError(t, err)
+
+	const requests = 16
+	codes := make([]int, requests)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/receptions", bytes.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			<-start
+			handler.ServeHTTP(rec, req)
+			codes[i] = rec.Code
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	created := 0
+	for _, code := range codes {
+		if code == http.StatusCreated {
+			created++
+			continue
+		}
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 1, countRows(t, "receptions"))
+}
+
+func Test_ReceptionInProgressUniqueIndex(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+
+	// bypasses the in-progress check done by the service to hit the index directly
+	err = repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+		_, err := repo.CreateReception(ctx, tx, pvz.Id)
+		return err
+	})
+	require.NoError(t, err)
+
+	err = repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+		_, err := repo.CreateReception(ctx, tx, pvz.Id)
+		return err
+	})
+	require.ErrorIs(t, err, customErrors.ErrReceptionInProgressAlreadyExists)
+
+	err = repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+		cur, err := repo.GetCurrentReception(ctx, tx, pvz.Id)
+		if err != nil {
+			return err
+		}
+		if _, err := repo.UpdateReceptionStatus(ctx, tx, cur.Id, model.ReceptionStatusClose, "8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a"); err != nil {
+			return err
+		}
+		_, err = repo.CreateReception(ctx, tx, pvz.Id)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, countRows(t, "receptions"))
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	customErrors "avito2/internal/errors"
+	"avito2/internal/handler_manager"
+	"avito2/internal/middleware"
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"avito2/internal/service"
+	"avito2/internal/utils"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReceptionTransitions(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+	userSvc := service.NewUserService(repository.NewUserRepository(database.DB), cfg.Auth.RefreshTokenTTL)
+	jwtGen := utils.NewJWTGen(keys, cfg.Auth.TokenTTL)
+	auth := middleware.NewAuthMiddleware(keys, userSvc)
+	hm := handler_manager.NewHandlerManager(svc, userSvc, jwtGen)
+
+	router := mux.NewRouter()
+	router.Handle("/receptions", auth.Handle(http.HandlerFunc(hm.CreateReception)))
+	router.Handle("/receptions/{receptionId}/cancel", auth.Handle(http.HandlerFunc(hm.CancelReception)))
+	router.Handle("/receptions/{receptionId}/reopen", auth.Handle(http.HandlerFunc(hm.ReopenReception)))
+	router.Handle("/pvz/{pvzId}/close_last_reception", auth.Handle(http.HandlerFunc(hm.CloseLastReception)))
+
+	employeeId := uuid.NewString()
+	employeeToken, err := jwtGen.GenerateJWT(employeeId, string(model.RoleEmployee))
+	require.NoError(t, err)
+	moderatorId := uuid.NewString()
+	moderatorToken, err := jwtGen.GenerateJWT(moderatorId, string(model.RoleModerator))
+	require.NoError(t, err)
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+
+	do := func(path, token string, body any) (*httptest.ResponseRecorder, model.Reception) {
+		t.Helper()
+		var reader *bytes.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		req := httptest.NewRequest(http.MethodPost, path, reader)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		var reception model.Reception
+		if rec.Code < http.StatusBadRequest {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reception))
+		}
+		return rec, reception
+	}
+
+	rec, reception := do("/receptions", employeeToken, model.CreateReceptionRequest{PvzId: pvz.Id.String()})
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec, closed := do("/pvz/"+pvz.Id.String()+"/close_last_reception", employeeToken, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, closed.ClosedAt)
+	assert.False(t, closed.ClosedAt.Before(reception.DateTime))
+	assert.Equal(t, employeeId, closed.ClosedBy)
+
+	rec, _ = do("/receptions/"+reception.Id.String()+"/reopen", employeeToken, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec, reopened := do("/receptions/"+reception.Id.String()+"/reopen", moderatorToken, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, model.ReceptionStatusInProgress, reopened.Status)
+	assert.Nil(t, reopened.ClosedAt)
+	assert.Empty(t, reopened.ClosedBy)
+
+	rec, cancelled := do("/receptions/"+reception.Id.String()+"/cancel", employeeToken, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, model.ReceptionStatusCancelled, cancelled.Status)
+
+	rec, _ = do("/receptions/"+reception.Id.String()+"/reopen", moderatorToken, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec, _ = do("/receptions/"+uuid.NewString()+"/cancel", employeeToken, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rows, err := database.DB.ExecQuery(ctx, `SELECT coalesce(from_status, ''), to_status, actor_id, actor_role
+		FROM reception_transitions WHERE reception_id = $1 ORDER BY created_at`, reception.Id)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type transition struct {
+		from, to, actorId, actorRole string
+	}
+	transitions := []transition{}
+	for rows.Next() {
+		var tr transition
+		require.NoError(t, rows.Scan(&tr.from, &tr.to, &tr.actorId, &tr.actorRole))
+		transitions = append(transitions, tr)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []transition{
+		{"", "in_progress", employeeId, "employee"},
+		{"in_progress", "close", employeeId, "employee"},
+		{"close", "in_progress", moderatorId, "moderator"},
+		{"in_progress", "cancelled", employeeId, "employee"},
+	}, transitions)
+}
+
+func Test_ReopenReceptionWindow(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	svc := service.NewService(repo)
+	employee := model.Actor{Id: uuid.NewString(), Role: model.RoleEmployee}
+	moderator := model.Actor{Id: uuid.NewString(), Role: model.RoleModerator}
+
+	pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+
+	closeReception := func(closedAgo string) uuid.UUID {
+		t.Helper()
+		reception, err := svc.CreateReception(ctx, pvz.Id, employee)
+		require.NoError(t, err)
+		_, err = svc.CloseLastReception(ctx, pvz.Id, employee)
+		require.NoError(t, err)
+		// shifts the stored close time without reading it back into Go
+		_, err = database.DB.Exec(ctx, "UPDATE receptions SET closed_at = closed_at - $2::interval WHERE id = $1", reception.Id, closedAgo)
+		require.NoError(t, err)
+		return reception.Id
+	}
+
+	expired := closeReception("25 hours")
+	_, err = svc.ReopenReception(ctx, expired, moderator)
+	require.ErrorIs(t, err, customErrors.ErrReceptionReopenWindowExpired)
+
+	recent := closeReception("23 hours")
+	reopened, err := svc.ReopenReception(ctx, recent, moderator)
+	require.NoError(t, err)
+	assert.Equal(t, model.ReceptionStatusInProgress, reopened.Status)
+}
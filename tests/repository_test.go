@@ -0,0 +1,292 @@
+package tests
+
+import (
+	"avito2/internal/model"
+	"avito2/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPvzInfoForPeriod(t *testing.T) {
+	t.Run("groups receptions and products by pvz", func(t *testing.T) {
+		database.SetUp(t, "pvz", "products", "receptions")
+		ctx := context.Background()
+		repo := repository.NewRepository(database.DB)
+
+		pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: "Москва"})
+		require.NoError(t, err)
+
+		tx, err := database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+		require.NoError(t, err)
+		reception, err := repo.CreateReception(ctx, tx, pvz.Id)
+		require.NoError(t, err)
+		for range 3 {
+			_, err = repo.AddProduct(ctx, tx, reception.Id, "обувь", "")
+			require.NoError(t, err)
+		}
+		require.NoError(t, tx.Commit(ctx))
+
+		tx, err = database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		pvzList, err := repo.GetPvzInfoForPeriod(ctx, tx, model.PvzInfoFilter{
+			StartDate: time.Time{},
+			EndDate:   time.Now().Add(time.Hour),
+			Page:      1,
+			Limit:     10,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, pvzList, 1)
+		assert.Equal(t, pvz.Id, pvzList[0].Pvz.Id)
+		require.Len(t, pvzList[0].Receptions, 1)
+		assert.Equal(t, reception.Id, pvzList[0].Receptions[0].Reception.Id)
+		assert.Len(t, pvzList[0].Receptions[0].Products, 3)
+	})
+}
+
+func Test_GetPvzInfoForPeriodPagination(t *testing.T) {
+	t.Run("paginates by pvz", func(t *testing.T) {
+		database.SetUp(t, "pvz", "products", "receptions")
+		ctx := context.Background()
+		repo := repository.NewRepository(database.DB)
+
+		var pvzWithReceptions []*model.Pvz
+		for range 3 {
+			pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+			require.NoError(t, err)
+			pvzWithReceptions = append(pvzWithReceptions, pvz)
+
+			tx, err := database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+			require.NoError(t, err)
+			for range 4 {
+				reception, err := repo.CreateReception(ctx, tx, pvz.Id)
+				require.NoError(t, err)
+				_, err = repo.UpdateReceptionStatus(ctx, tx, reception.Id, model.ReceptionStatusClose, "8f1b7a52-6c5e-4d4a-9a8e-2f3b1c0d9e7a")
+				require.NoError(t, err)
+			}
+			require.NoError(t, tx.Commit(ctx))
+		}
+		emptyPvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityKazan})
+		require.NoError(t, err)
+
+		tx, err := database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		filter := model.PvzInfoFilter{
+			StartDate: time.Time{},
+			EndDate:   time.Now().Add(time.Hour),
+			Page:      1,
+			Limit:     2,
+		}
+		firstPage, err := repo.GetPvzInfoForPeriod(ctx, tx, filter)
+		require.NoError(t, err)
+		require.Len(t, firstPage, 2)
+		assert.Equal(t, pvzWithReceptions[2].Id, firstPage[0].Pvz.Id)
+		assert.Equal(t, pvzWithReceptions[1].Id, firstPage[1].Pvz.Id)
+		for _, pvzInfo := range firstPage {
+			assert.Len(t, pvzInfo.Receptions, 4)
+		}
+
+		filter.Page = 2
+		secondPage, err := repo.GetPvzInfoForPeriod(ctx, tx, filter)
+		require.NoError(t, err)
+		require.Len(t, secondPage, 1)
+		assert.Equal(t, pvzWithReceptions[0].Id, secondPage[0].Pvz.Id)
+
+		filter.Cursor = &model.PvzCursor{RegistrationDate: firstPage[1].Pvz.RegistrationDate, Id: firstPage[1].Pvz.Id}
+		nextPage, err := repo.GetPvzInfoForPeriod(ctx, tx, filter)
+		require.NoError(t, err)
+		require.Len(t, nextPage, 1)
+		assert.Equal(t, pvzWithReceptions[0].Id, nextPage[0].Pvz.Id)
+
+		filter.Cursor = nil
+		filter.Page = 1
+		filter.IncludeEmpty = true
+		withEmpty, err := repo.GetPvzInfoForPeriod(ctx, tx, filter)
+		require.NoError(t, err)
+		require.Len(t, withEmpty, 2)
+		assert.Equal(t, emptyPvz.Id, withEmpty[0].Pvz.Id)
+		assert.Empty(t, withEmpty[0].Receptions)
+	})
+}
+
+func Test_GetPvzInfoForPeriodFilters(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+
+	start := time.Now().Add(-24 * time.Hour)
+	addReception := func(pvzId uuid.UUID, status model.ReceptionStatus, duration time.Duration) uuid.UUID {
+		var closedAt *time.Time
+		if status == model.ReceptionStatusClose {
+			end := start.Add(duration)
+			closedAt = &end
+		}
+		var id uuid.UUID
+		err := database.DB.ExecQueryRow(ctx, "INSERT INTO receptions (date_time, pvz_id, status, closed_at) VALUES ($1, $2, $3, $4) RETURNING id",
+			start, pvzId, status, closedAt).Scan(&id)
+		require.NoError(t, err)
+		return id
+	}
+
+	shortPvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+	shortReception := addReception(shortPvz.Id, model.ReceptionStatusClose, 10*time.Minute)
+
+	longPvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+	require.NoError(t, err)
+	longReception := addReception(longPvz.Id, model.ReceptionStatusClose, 2*time.Hour)
+	longOpenReception := addReception(longPvz.Id, model.ReceptionStatusInProgress, 0)
+
+	openPvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityKazan})
+	require.NoError(t, err)
+	openReception := addReception(openPvz.Id, model.ReceptionStatusInProgress, 0)
+
+	emptyPvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityKazan})
+	require.NoError(t, err)
+
+	minutes := func(n int) *time.Duration {
+		d := time.Duration(n) * time.Minute
+		return &d
+	}
+
+	cases := []struct {
+		name   string
+		filter model.PvzInfoFilter
+		// want maps every returned pvz, newest first, to its receptions.
+		want []pvzReceptions
+	}{
+		{
+			name:   "closed receptions",
+			filter: model.PvzInfoFilter{Status: model.ReceptionStatusClose},
+			want: []pvzReceptions{
+				{longPvz.Id, []uuid.UUID{longReception}},
+				{shortPvz.Id, []uuid.UUID{shortReception}},
+			},
+		},
+		{
+			name:   "in progress receptions",
+			filter: model.PvzInfoFilter{Status: model.ReceptionStatusInProgress},
+			want: []pvzReceptions{
+				{openPvz.Id, []uuid.UUID{openReception}},
+				{longPvz.Id, []uuid.UUID{longOpenReception}},
+			},
+		},
+		{
+			name:   "min duration",
+			filter: model.PvzInfoFilter{MinDuration: minutes(60)},
+			want: []pvzReceptions{
+				{longPvz.Id, []uuid.UUID{longReception}},
+			},
+		},
+		{
+			name:   "max duration",
+			filter: model.PvzInfoFilter{MaxDuration: minutes(30)},
+			want: []pvzReceptions{
+				{shortPvz.Id, []uuid.UUID{shortReception}},
+			},
+		},
+		{
+			name:   "duration bounds exclude in progress receptions",
+			filter: model.PvzInfoFilter{MinDuration: minutes(5), MaxDuration: minutes(180)},
+			want: []pvzReceptions{
+				{longPvz.Id, []uuid.UUID{longReception}},
+				{shortPvz.Id, []uuid.UUID{shortReception}},
+			},
+		},
+		{
+			name:   "in progress status with a duration bound",
+			filter: model.PvzInfoFilter{Status: model.ReceptionStatusInProgress, MaxDuration: minutes(180)},
+			want:   []pvzReceptions{},
+		},
+		{
+			name:   "include empty keeps pvz without matching receptions",
+			filter: model.PvzInfoFilter{MinDuration: minutes(60), IncludeEmpty: true},
+			want: []pvzReceptions{
+				{emptyPvz.Id, []uuid.UUID{}},
+				{openPvz.Id, []uuid.UUID{}},
+				{longPvz.Id, []uuid.UUID{longReception}},
+				{shortPvz.Id, []uuid.UUID{}},
+			},
+		},
+		{
+			name:   "include empty with status",
+			filter: model.PvzInfoFilter{Status: model.ReceptionStatusInProgress, IncludeEmpty: true},
+			want: []pvzReceptions{
+				{emptyPvz.Id, []uuid.UUID{}},
+				{openPvz.Id, []uuid.UUID{openReception}},
+				{longPvz.Id, []uuid.UUID{longOpenReception}},
+				{shortPvz.Id, []uuid.UUID{}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx, err := database.DB.BeginTx(ctx, &pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+			require.NoError(t, err)
+			defer tx.Rollback(ctx)
+
+			filter := tc.filter
+			filter.EndDate = time.Now().Add(time.Hour)
+			filter.Page = 1
+			filter.Limit = 10
+			pvzList, err := repo.GetPvzInfoForPeriod(ctx, tx, filter)
+			require.NoError(t, err)
+
+			got := make([]pvzReceptions, 0, len(pvzList))
+			for _, pvzInfo := range pvzList {
+				receptions := []uuid.UUID{}
+				for _, reception := range pvzInfo.Receptions {
+					receptions = append(receptions, reception.Reception.Id)
+				}
+				got = append(got, pvzReceptions{pvzInfo.Pvz.Id, receptions})
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+type pvzReceptions struct {
+	PvzId      uuid.UUID
+	Receptions []uuid.UUID
+}
+
+func Test_GetPvzPage(t *testing.T) {
+	database.SetUp(t, "pvz", "products", "receptions")
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+
+	var created []uuid.UUID
+	for range 5 {
+		pvz, err := repo.CreatePvz(ctx, model.CreatePvzRequest{City: model.CityMoscow})
+		require.NoError(t, err)
+		created = append(created, pvz.Id)
+	}
+
+	var read []uuid.UUID
+	var after *model.PvzCursor
+	for {
+		page, err := repo.GetPvzPage(ctx, after, 2)
+		require.NoError(t, err)
+		for _, pvz := range page {
+			read = append(read, pvz.Id)
+		}
+		if len(page) < 2 {
+			break
+		}
+		last := page[len(page)-1]
+		after = &model.PvzCursor{RegistrationDate: last.RegistrationDate, Id: last.Id}
+	}
+
+	assert.Equal(t, created, read)
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"avito2/internal/repository"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countRows(t *testing.T, table string) int {
+	t.Helper()
+	var count int
+	err := database.DB.ExecQueryRow(context.Background(), "SELECT count(*) FROM "+table).Scan(&count)
+	require.NoError(t, err)
+	return count
+}
+
+func Test_WithTx(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewRepository(database.DB)
+	insertPvz := func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "INSERT INTO pvz (registration_date, city) VALUES (now(), 'Москва')")
+		return err
+	}
+
+	t.Run("commits on success", func(t *testing.T) {
+		database.SetUp(t, "pvz")
+
+		err := repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, insertPvz)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, countRows(t, "pvz"))
+	})
+	t.Run("rolls back on error", func(t *testing.T) {
+		database.SetUp(t, "pvz")
+		fnErr := errors.New("fn error")
+
+		err := repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+			require.NoError(t, insertPvz(tx))
+			return fnErr
+		})
+
+		require.ErrorIs(t, err, fnErr)
+		assert.Equal(t, 0, countRows(t, "pvz"))
+	})
+	t.Run("rolls back on panic", func(t *testing.T) {
+		database.SetUp(t, "pvz")
+
+		assert.Panics(t, func() {
+			_ = repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+				require.NoError(t, insertPvz(tx))
+				panic("boom")
+			})
+		})
+		assert.Equal(t, 0, countRows(t, "pvz"))
+	})
+	t.Run("retries serialization failure", func(t *testing.T) {
+		database.SetUp(t, "pvz")
+		attempts := 0
+
+		err := repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+			attempts++
+			if err := insertPvz(tx); err != nil {
+				return err
+			}
+			if attempts == 1 {
+				return &pgconn.PgError{Code: pgerrcode.SerializationFailure}
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+		assert.Equal(t, 1, countRows(t, "pvz"))
+	})
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+
+		err := repo.WithTx(ctx, &pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+			attempts++
+			return &pgconn.PgError{Code: pgerrcode.UniqueViolation}
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, attempts)
+	})
+}